@@ -0,0 +1,114 @@
+package artwork
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	Poster = "poster"
+	Fanart = "fanart"
+
+	defaultExt = ".jpg"
+	timeout    = 30 * time.Second
+)
+
+var (
+	//DefaultURLs are the URLs of each database's images, which the artwork
+	//paths it returns are relative to
+	DefaultURLs = map[string]string{
+		"TMDB": "https://image.tmdb.org/t/p/original",
+		"TVDB": "https://artworks.thetvdb.com/banners",
+	}
+)
+
+//Downloader downloads artwork from a database's images
+type Downloader struct {
+	client *http.Client
+	url    string
+}
+
+//New returns a Downloader of the artwork of the database provider from url,
+//or from the provider's default URL if url is empty
+func New(provider, url string) (*Downloader, error) {
+
+	if url == "" {
+		var ok bool
+		if url, ok = DefaultURLs[provider]; !ok {
+			return nil, fmt.Errorf("no artwork URL for database %s", provider)
+		}
+	}
+
+	return &Downloader{
+		client: &http.Client{Timeout: timeout},
+		url:    strings.TrimSuffix(url, "/"),
+	}, nil
+}
+
+//Download downloads the artwork at path, relative to the downloader's URL,
+//to dest. Existing files are left alone, returning os.ErrExist.
+func (d *Downloader) Download(path, dest string) error {
+
+	if _, err := os.Lstat(dest); err == nil {
+		return os.ErrExist
+	}
+
+	resp, err := d.client.Get(d.url + "/" + strings.TrimPrefix(path, "/"))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response status: %d", resp.StatusCode)
+	}
+
+	//written to a temporary file first, so a failed download leaves nothing
+	temp, err := ioutil.TempFile(filepath.Dir(dest), ".artwork-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+
+	if _, err := io.Copy(temp, resp.Body); err != nil {
+		temp.Close()
+		return err
+	}
+
+	if err := temp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(temp.Name(), dest)
+}
+
+//Name returns the file name Plex and Kodi recognise for artwork of kind
+//(e.g. Poster) at path, given the prefix of the media it's for, e.g.
+//"Movie (2010)-poster.jpg". The extension of the artwork is kept.
+func Name(prefix, kind, artworkPath string) string {
+
+	ext := strings.ToLower(path.Ext(artworkPath))
+	if ext == "" {
+		ext = defaultExt
+	}
+
+	return prefix + kind + ext
+}
+
+//SeasonPrefix returns the prefix of the artwork of a season, as kept in its
+//show's directory, e.g. "season02-"
+func SeasonPrefix(season int) string {
+
+	if season == 0 {
+		return "season-specials-"
+	}
+
+	return fmt.Sprintf("season%02d-", season)
+}
@@ -0,0 +1,81 @@
+package artwork
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDownloader_Download(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/images/poster.jpg" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("poster"))
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "media-mapper-artwork")
+	if err != nil {
+		t.Fatalf("unable to create dir: %s", err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	downloader, err := New("TMDB", server.URL+"/images/")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	dest := filepath.Join(dir, "poster.jpg")
+	if err := downloader.Download("/poster.jpg", dest); err != nil {
+		t.Fatalf("unexpected error downloading: %s", err.Error())
+	}
+
+	if data, err := ioutil.ReadFile(dest); err != nil || string(data) != "poster" {
+		t.Errorf("expected downloaded poster, got %q (%v)", data, err)
+	}
+
+	//existing files are left alone
+	if err := downloader.Download("/poster.jpg", dest); !os.IsExist(err) {
+		t.Errorf("expected error downloading to existing file, got %v", err)
+	}
+
+	//failed downloads leave nothing behind
+	if err := downloader.Download("/missing.jpg", filepath.Join(dir, "fanart.jpg")); err == nil {
+		t.Errorf("expected error downloading missing artwork, got none")
+	}
+
+	if files, _ := ioutil.ReadDir(dir); len(files) != 1 {
+		t.Errorf("expected only the poster to be downloaded, got %d files", len(files))
+	}
+
+	if _, err := New("IMDB", ""); err == nil {
+		t.Errorf("expected error for database without artwork URL, got none")
+	}
+}
+
+func TestName(t *testing.T) {
+
+	var tests = []struct {
+		prefix   string
+		kind     string
+		path     string
+		expected string
+	}{
+		{prefix: "", kind: Poster, path: "/abc.jpg", expected: "poster.jpg"},
+		{prefix: "Movie (2010)-", kind: Fanart, path: "/abc.PNG", expected: "Movie (2010)-fanart.png"},
+		{prefix: SeasonPrefix(2), kind: Poster, path: "posters/1-2.jpg", expected: "season02-poster.jpg"},
+		{prefix: SeasonPrefix(0), kind: Poster, path: "noext", expected: "season-specials-poster.jpg"},
+	}
+
+	for _, test := range tests {
+		if actual := Name(test.prefix, test.kind, test.path); actual != test.expected {
+			t.Errorf("Name(%q, %q, %q) expected %q, got %q", test.prefix, test.kind, test.path, test.expected, actual)
+		}
+	}
+}
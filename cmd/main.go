@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bufio"
 	"encoding/base64"
 	"flag"
 	"fmt"
@@ -8,15 +9,26 @@ import (
 	"log"
 	"os"
 	"strings"
+	"time"
 
+	colour "github.com/fatih/color"
+	"github.com/rustedturnip/media-mapper/artwork"
 	cfg "github.com/rustedturnip/media-mapper/config"
 	"github.com/rustedturnip/media-mapper/controller"
 	"github.com/rustedturnip/media-mapper/dbs"
+	"github.com/rustedturnip/media-mapper/dbs/cache"
+	"github.com/rustedturnip/media-mapper/dbs/memo"
 	"github.com/rustedturnip/media-mapper/filing"
 )
 
 const (
 	version = "v0.4.0"
+
+	cmdCache = "cache"
+	cmdUndo  = "undo"
+	cmdApply = "apply"
+
+	stdout = "-"
 )
 
 var (
@@ -27,6 +39,24 @@ var (
 	database       string
 	auth           string
 	location       string
+	confidence     float64
+	workers        int
+	offline        bool
+	cacheEnabled   bool
+	cacheDir       string
+	searchTTL      time.Duration
+	showTTL        time.Duration
+	configPath     string
+	schemeName     string
+	order          string
+	organise       bool
+	destination    string
+	conflicts      string
+	journalDir     string
+	planPath       string
+	nfoFlag        bool
+	artworkFlag    bool
+	artworkURL     string
 )
 
 func init() {
@@ -37,6 +67,25 @@ func init() {
 	flag.StringVar(&database, "database", "TMDB", "database to extract data from")
 	flag.StringVar(&auth, "auth", "", "location of auth")
 	flag.StringVar(&location, "location", "", "location of files to be formatted")
+	flag.Float64Var(&confidence, "confidence", 0.5, "minimum match confidence (0-1) required to rename a file")
+	flag.IntVar(&workers, "workers", 4, "number of files to look up concurrently")
+	flag.StringVar(&configPath, "config", "", "location of config (default is media-mapper/config.json under the user config dir)")
+	flag.StringVar(&schemeName, "scheme", "", "naming scheme to use, overriding that of the library being formatted")
+	flag.StringVar(&order, "order", "", "order episodes are numbered in: aired, dvd or absolute, overriding the config's orders")
+	flag.BoolVar(&organise, "organise", false, "move files into show/season and movie directories named by the naming scheme")
+	flag.StringVar(&destination, "destination", "", "directory files are organised into (default is location)")
+	flag.StringVar(&journalDir, "journal", "", "location of the journal of renames used to undo them (default is media-mapper/journal under the user config dir)")
+	flag.StringVar(&planPath, "plan", "", "write the renames as a JSON plan to this file (- for stdout) instead of renaming, apply it later with the apply command")
+	flag.BoolVar(&nfoFlag, "nfo", false, "write Kodi/Jellyfin .nfo metadata files beside the renamed files")
+	flag.BoolVar(&artworkFlag, "artwork", false, "download posters and fanart beside the renamed files")
+	flag.StringVar(&artworkURL, "artwork-url", "", "base URL artwork is downloaded from (default is the database's image URL)")
+	flag.StringVar(&conflicts, "conflicts", "skip", "how renames to the same or an existing file are resolved: skip, suffix, quality or replace")
+
+	flag.BoolVar(&offline, "offline", false, "answer lookups purely from the cache, without contacting the database")
+	flag.BoolVar(&cacheEnabled, "cache", true, "cache database lookups on disk between runs")
+	flag.StringVar(&cacheDir, "cache-dir", "", "location of the cache (default is media-mapper under the user cache dir)")
+	flag.DurationVar(&searchTTL, "search-ttl", 24*time.Hour, "how long search results are cached for")
+	flag.DurationVar(&showTTL, "show-ttl", 7*24*time.Hour, "how long show, series and movie data is cached for")
 
 	flag.Parse()
 }
@@ -48,32 +97,199 @@ func main() {
 		return
 	}
 
+	if flag.Arg(0) == cmdCache {
+		runCache(flag.Args()[1:])
+		return
+	}
+
+	if flag.Arg(0) == cmdUndo {
+		runUndo(flag.Args()[1:])
+		return
+	}
+
+	if flag.Arg(0) == cmdApply {
+		runApply(flag.Args()[1:])
+		return
+	}
+
+	if flag.NArg() > 0 {
+		log.Fatalf("Unknown command: %s", flag.Arg(0))
+	}
+
 	//create DB instance
 	db, ok := dbs.API_value[database]
 	if !ok {
 		log.Fatalf("Unssupported network specified: %s", database)
 	}
 
-	authReader, err := getAuthReader()
-	if err != nil {
-		log.Fatalf(err.Error())
+	if offline && !cacheEnabled {
+		log.Fatalf("The cache must be enabled to run offline")
 	}
 
-	api, err := cfg.GetInstance(authReader, db)
-	if err != nil {
-		log.Fatalf("Unable to create network instance for %s with error - %s", database, err.Error())
+	var api dbs.Database
+	var err error
+
+	//offline runs never contact the database, so don't need credentials
+	if !offline {
+		authReader, err := getAuthReader()
+		if err != nil {
+			log.Fatalf(err.Error())
+		}
+
+		api, err = cfg.GetInstance(authReader, db)
+		if err != nil {
+			log.Fatalf("Unable to create network instance for %s with error - %s", database, err.Error())
+		}
 	}
 
+	if cacheEnabled {
+		dir, err := getCacheDir()
+		if err != nil {
+			log.Fatalf("Unable to locate cache with error - %s", err.Error())
+		}
+
+		api = cache.New(api, dbs.API_name[int(db)], dir, getTTL(), offline)
+	}
+
+	//files in the same show or directory make many identical lookups
+	api = memo.New(api)
+
 	//create Filer instance
 	var filer *filing.Filer
 	if filer, err = filing.New(location); err != nil {
 		log.Fatalf("File handler failed to initialise: %s", err.Error())
 	}
 
-	worker := controller.New(api, filer, streamlineFlag)
+	settings, err := getSettings()
+	if err != nil {
+		log.Fatalf("Unable to read config with error - %s", err.Error())
+	}
+
+	filer.AttachSidecars(settings.Sidecars)
+
+	scheme, err := settings.Naming.Scheme(schemeName, location)
+	if err != nil {
+		log.Fatalf("Unable to select naming scheme with error - %s", err.Error())
+	}
+
+	//the order flag applies to every show, replacing those of the config
+	episodeOrder, showOrders := settings.Order.Default, settings.Order.Shows[dbs.API_name[int(db)]]
+	if order != "" {
+		episodeOrder, showOrders = getOrder(), nil
+	}
+
+	worker := controller.New(api, filer, controller.Options{
+		Streamline:    streamlineFlag,
+		MinConfidence: confidence,
+		Workers:       workers,
+		Provider:      dbs.API_name[int(db)],
+		Scheme:        scheme,
+		Sanitiser:     settings.Sanitise,
+		Order:         episodeOrder,
+		ShowOrders:    showOrders,
+		Organise:      organise,
+		Destination:   destination,
+		Conflicts:     getStrategy(),
+		Journal:       getJournal(),
+		NFO:           nfoFlag,
+		Artwork:       getArtwork(),
+	})
+
+	if planPath != "" {
+		writePlan(worker.Plan())
+		return
+	}
+
 	worker.Do()
 }
 
+//returns the artwork downloader for the database, or nil if artwork isn't
+//downloaded
+func getArtwork() *artwork.Downloader {
+
+	if !artworkFlag {
+		return nil
+	}
+
+	downloader, err := artwork.New(database, artworkURL)
+	if err != nil {
+		log.Fatalf("Unable to download artwork - %s", err.Error())
+	}
+
+	return downloader
+}
+
+func getOrder() dbs.Order {
+
+	o, ok := dbs.Orders[order]
+	if !ok {
+		log.Fatalf("Unsupported episode order specified: %s", order)
+	}
+
+	return o
+}
+
+func getStrategy() filing.Strategy {
+
+	strategy, ok := filing.Strategies[conflicts]
+	if !ok {
+		log.Fatalf("Unsupported conflict strategy specified: %s", conflicts)
+	}
+
+	return strategy
+}
+
+func writePlan(plan *filing.Plan) {
+
+	if planPath == stdout {
+		if err := plan.Write(os.Stdout); err != nil {
+			log.Fatalf("Unable to write plan with error - %s", err.Error())
+		}
+		return
+	}
+
+	file, err := os.Create(planPath)
+	if err != nil {
+		log.Fatalf("Unable to create plan with error - %s", err.Error())
+	}
+	defer file.Close()
+
+	if err := plan.Write(file); err != nil {
+		log.Fatalf("Unable to write plan with error - %s", err.Error())
+	}
+	fmt.Printf("Plan of %d files written to %s, apply it with: media-mapper apply %s\n", len(plan.Items), planPath, planPath)
+}
+
+//runApply handles the apply command, which renames files as described by a
+//plan previously written with the plan flag
+func runApply(args []string) {
+
+	if len(args) != 1 {
+		log.Fatalf("Usage: media-mapper apply <plan file>")
+	}
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		log.Fatalf("Unable to open plan with error - %s", err.Error())
+	}
+	defer file.Close()
+
+	plan, err := filing.ReadPlan(file)
+	if err != nil {
+		log.Fatalf("Unable to read plan with error - %s", err.Error())
+	}
+
+	filer, errs := filing.NewFromPlan(plan)
+
+	//files have their new names so no database is needed
+	worker := controller.New(nil, filer, controller.Options{
+		Streamline: streamlineFlag,
+		Conflicts:  getStrategy(),
+		Journal:    getJournal(),
+	})
+	worker.Apply(errs)
+}
+
 func getAuthReader() (io.Reader, error) {
 
 	if auth != "" {
@@ -87,3 +303,186 @@ func getAuthReader() (io.Reader, error) {
 
 	return nil, fmt.Errorf("failed to find database credentials")
 }
+
+//reads the config file, using empty settings if the default config file
+//doesn't exist
+func getSettings() (*cfg.Settings, error) {
+
+	path := configPath
+	if path == "" {
+		var err error
+		if path, err = cfg.DefaultSettingsPath(); err != nil {
+			return cfg.LoadSettings(strings.NewReader("{}"))
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) && configPath == "" {
+			return cfg.LoadSettings(strings.NewReader("{}"))
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	return cfg.LoadSettings(file)
+}
+
+func getCacheDir() (string, error) {
+
+	if cacheDir != "" {
+		return cacheDir, nil
+	}
+
+	return cache.DefaultDir()
+}
+
+func getTTL() cache.TTL {
+	return cache.TTL{
+		Search: searchTTL,
+		Show:   showTTL,
+	}
+}
+
+//runCache handles the cache command, which either lists the cached lookups
+//or purges them (all, or only those that have expired)
+func runCache(args []string) {
+
+	dir, err := getCacheDir()
+	if err != nil {
+		log.Fatalf("Unable to locate cache with error - %s", err.Error())
+	}
+
+	if len(args) == 0 {
+		log.Fatalf("Usage: media-mapper cache list | purge [expired]")
+	}
+
+	switch args[0] {
+	case "list":
+		entries, err := cache.List(dir)
+		if err != nil {
+			log.Fatalf("Unable to list cache with error - %s", err.Error())
+		}
+
+		now := time.Now()
+		for _, entry := range entries {
+			status := ""
+			if entry.Expired(getTTL(), now) {
+				status = " (expired)"
+			}
+
+			fmt.Printf("%s\t%s\t%s\t%s%s\n", entry.Provider, entry.Kind, entry.Key,
+				entry.Stored.Format(time.RFC3339), status)
+		}
+		fmt.Printf("%d entries in %s\n", len(entries), dir)
+
+	case "purge":
+		expiredOnly := len(args) > 1 && args[1] == "expired"
+
+		removed, err := cache.Purge(dir, getTTL(), expiredOnly)
+		if err != nil {
+			log.Fatalf("Unable to purge cache with error - %s", err.Error())
+		}
+		fmt.Printf("Removed %d entries from %s\n", removed, dir)
+
+	default:
+		log.Fatalf("Unknown cache command: %s", args[0])
+	}
+}
+
+//returns the journal renames are recorded in, or nil if it can't be located
+func getJournal() *filing.Journal {
+
+	dir := journalDir
+	if dir == "" {
+		var err error
+		if dir, err = filing.DefaultJournalDir(); err != nil {
+			log.Println(fmt.Sprintf("Warning: renames won't be recorded, unable to locate journal - %s", err.Error()))
+			return nil
+		}
+	}
+
+	return filing.NewJournal(dir)
+}
+
+//runUndo handles the undo command, which either lists the recorded runs or
+//restores the files renamed by one (by ID, or the last run)
+func runUndo(args []string) {
+
+	journal := getJournal()
+	if journal == nil {
+		log.Fatalf("Unable to locate journal")
+	}
+
+	runs, err := journal.Runs()
+	if err != nil {
+		log.Fatalf("Unable to read journal with error - %s", err.Error())
+	}
+
+	if len(args) == 0 {
+		for _, run := range runs {
+			fmt.Printf("%s\t%s\t%d renamed, %d written (%d to undo)\n", run.ID, run.Time.Format(time.RFC1123), len(run.Renamed), len(run.Written), run.Pending())
+		}
+		fmt.Printf("%d runs, undo one with: media-mapper undo <id|last>\n", len(runs))
+		return
+	}
+
+	var run *filing.Run
+	if args[0] == "last" {
+		if len(runs) == 0 {
+			log.Fatalf("No runs to undo")
+		}
+		run = runs[0]
+	} else if run, err = journal.Get(args[0]); err != nil {
+		log.Fatalf(err.Error())
+	}
+
+	if run.Pending() == 0 {
+		fmt.Printf("Run %s has already been undone\n", run.ID)
+		return
+	}
+
+	fmt.Printf("\nundo %s:\n", run.ID)
+	for _, renamed := range run.Renamed {
+		if renamed.Undone {
+			continue
+		}
+
+		colour.Red("- %s", renamed.New)
+		colour.Green("+ %s", renamed.Old)
+		if renamed.Replaced {
+			colour.Yellow("! the file this replaced can't be restored")
+		}
+		fmt.Println()
+	}
+
+	for _, written := range run.Written {
+		colour.Red("- %s", written)
+	}
+	if len(run.Written) > 0 {
+		fmt.Println()
+	}
+
+	for _, deleted := range run.Deleted {
+		colour.Yellow("! %s was deleted and can't be restored", deleted)
+	}
+	if len(run.Deleted) > 0 {
+		fmt.Println()
+	}
+
+	if !streamlineFlag {
+		fmt.Print("Proceed with undo? (y/n): ")
+		text, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if strings.ToLower(strings.Trim(text, " \r\n")) != "y" {
+			fmt.Println("Cancelling...")
+			return
+		}
+	}
+
+	pending := run.Pending()
+	errs := journal.Undo(run)
+	for _, err := range errs {
+		colour.Yellow("! %s", err.Error())
+	}
+	fmt.Printf("Undid %d of %d changes\n", pending-run.Pending(), pending)
+}
@@ -0,0 +1,192 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rustedturnip/media-mapper/dbs"
+	"github.com/rustedturnip/media-mapper/filing"
+	"github.com/rustedturnip/media-mapper/naming"
+)
+
+const (
+	settingsDir  = "media-mapper"
+	settingsFile = "config.json"
+)
+
+//Settings are the user's preferences, read from a JSON config file
+type Settings struct {
+	Naming   *Naming           `json:"naming"`
+	Sanitise *naming.Sanitiser `json:"sanitise"` //rules not given in config keep their defaults
+	Sidecars filing.Rules      `json:"sidecars"` //as with sanitise rules
+	Order    *Order            `json:"order"`
+}
+
+//Order selects the order episodes are numbered in, for every show and for
+//individual shows by their ID in each database. Shows in TMDB can also be
+//given the ID of one of their episode groups.
+type Order struct {
+	Default dbs.Order                    `json:"default"` //aired if not given
+	Shows   map[string]map[int]dbs.Order `json:"shows"`   //key: database, e.g. TMDB; value: orders by show ID
+}
+
+//Naming defines the user's naming schemes and which is used for each library
+type Naming struct {
+	Default   string                       `json:"default"` //scheme used outside of any library
+	Schemes   map[string]*naming.Templates `json:"schemes"`
+	Libraries []*Library                   `json:"libraries"`
+}
+
+//Library is a directory of media whose files are named with a given scheme
+type Library struct {
+	Path   string `json:"path"`
+	Scheme string `json:"scheme"`
+}
+
+//DefaultSettingsPath returns the location of the config file under the
+//user's config dir
+func DefaultSettingsPath() (string, error) {
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, settingsDir, settingsFile), nil
+}
+
+func LoadSettings(reader io.Reader) (*Settings, error) {
+
+	settings := &Settings{
+		Sanitise: naming.NewSanitiser(),
+		Sidecars: filing.DefaultRules(),
+	}
+	if err := json.NewDecoder(reader).Decode(&settings); err != nil {
+		return nil, err
+	}
+
+	if settings == nil {
+		settings = &Settings{}
+	}
+
+	if settings.Naming == nil {
+		settings.Naming = &Naming{}
+	}
+
+	if settings.Sanitise == nil {
+		settings.Sanitise = naming.NewSanitiser()
+	}
+
+	if settings.Sidecars == nil {
+		settings.Sidecars = filing.DefaultRules()
+	}
+
+	if err := settings.Sidecars.Validate(); err != nil {
+		return nil, err
+	}
+
+	if settings.Order == nil {
+		settings.Order = &Order{}
+	}
+
+	if err := settings.Order.Validate(); err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+//Validate checks the orders are ones the databases support
+func (o *Order) Validate() error {
+
+	if _, ok := dbs.Orders[string(o.Default)]; !ok && o.Default != "" {
+		return fmt.Errorf("unknown episode order %q", o.Default)
+	}
+
+	for database, shows := range o.Shows {
+		api, ok := dbs.API_value[database]
+		if !ok {
+			return fmt.Errorf("unknown database %q for episode orders", database)
+		}
+
+		for id, order := range shows {
+			if _, ok := dbs.Orders[string(order)]; ok {
+				continue
+			}
+
+			//only TMDB has episode groups
+			if api != dbs.TMDB || order == "" {
+				return fmt.Errorf("unknown episode order %q for %s show %d", order, database, id)
+			}
+		}
+	}
+
+	return nil
+}
+
+//Scheme returns the naming scheme called name or, if no name is given, that
+//of the library containing location, falling back to the default scheme.
+//Schemes defined in config take precedence over the built in schemes.
+func (n *Naming) Scheme(name, location string) (*naming.Scheme, error) {
+
+	if name == "" {
+		name = n.libraryScheme(location)
+	}
+
+	if name == "" {
+		name = n.Default
+	}
+
+	if name == "" {
+		name = naming.DefaultScheme
+	}
+
+	if s, ok := n.Schemes[name]; ok {
+		return naming.NewScheme(name, s)
+	}
+
+	if s, ok := naming.Schemes[name]; ok {
+		return s, nil
+	}
+
+	return nil, fmt.Errorf("unknown naming scheme: %s", name)
+}
+
+//returns the scheme of the most specific library containing location, or
+//an empty string if location isn't in a library
+func (n *Naming) libraryScheme(location string) string {
+
+	location, err := filepath.Abs(location)
+	if err != nil {
+		return ""
+	}
+
+	var match *Library
+	matchPath := ""
+	for _, library := range n.Libraries {
+		path, err := filepath.Abs(library.Path)
+		if err != nil {
+			continue
+		}
+
+		rel, err := filepath.Rel(path, location)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+
+		if match == nil || len(path) > len(matchPath) {
+			match = library
+			matchPath = path
+		}
+	}
+
+	if match == nil {
+		return ""
+	}
+
+	return match.Scheme
+}
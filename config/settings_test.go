@@ -0,0 +1,162 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/rustedturnip/media-mapper/dbs"
+	"github.com/rustedturnip/media-mapper/filing"
+	"github.com/rustedturnip/media-mapper/naming"
+)
+
+const testSettings = `{
+	"naming": {
+		"default": "plex",
+		"schemes": {
+			"anime": {
+				"movie": "{{.Title}} ({{.Year}})",
+				"tv": "{{.Title}} - {{pad 3 .Episode}}"
+			}
+		},
+		"libraries": [
+			{"path": "/media", "scheme": "default"},
+			{"path": "/media/anime", "scheme": "anime"}
+		]
+	}
+}`
+
+func TestNaming_Scheme(t *testing.T) {
+
+	settings, err := LoadSettings(strings.NewReader(testSettings))
+	if err != nil {
+		t.Fatalf("unexpected error loading settings: %s", err.Error())
+	}
+
+	var tests = []struct {
+		name     string
+		scheme   string
+		location string
+		expected string
+	}{
+		{
+			name:     "Outside Libraries",
+			location: "/downloads",
+			expected: "plex",
+		},
+		{
+			name:     "Library",
+			location: "/media/tv/Broadchurch",
+			expected: "default",
+		},
+		{
+			name:     "Most Specific Library",
+			location: "/media/anime/Naruto",
+			expected: "anime",
+		},
+		{
+			name:     "Similar Path",
+			location: "/media/animation",
+			expected: "default",
+		},
+		{
+			name:     "Named",
+			scheme:   "anime",
+			location: "/media/tv",
+			expected: "anime",
+		},
+	}
+
+	for _, test := range tests {
+		scheme, err := settings.Naming.Scheme(test.scheme, test.location)
+		if err != nil {
+			t.Errorf("%s unexpected error: %s", test.name, err.Error())
+			continue
+		}
+
+		if scheme.Name != test.expected {
+			t.Errorf("%s expected scheme %q, got %q", test.name, test.expected, scheme.Name)
+		}
+	}
+
+	if _, err := settings.Naming.Scheme("unknown", "/media"); err == nil {
+		t.Errorf("Unknown Scheme expected error, got none")
+	}
+
+	//no config uses the default scheme
+	empty, err := LoadSettings(strings.NewReader("{}"))
+	if err != nil {
+		t.Fatalf("unexpected error loading settings: %s", err.Error())
+	}
+
+	if scheme, err := empty.Naming.Scheme("", "/media"); err != nil || scheme.Name != "default" {
+		t.Errorf("Empty Settings expected default scheme, got %v (%v)", scheme, err)
+	}
+}
+
+func TestLoadSettings_Sanitise(t *testing.T) {
+
+	settings, err := LoadSettings(strings.NewReader(`{"sanitise": {"colon": "", "ascii": true}}`))
+	if err != nil {
+		t.Fatalf("unexpected error loading settings: %s", err.Error())
+	}
+
+	//rules not given keep their defaults
+	expected := naming.NewSanitiser()
+	expected.Colon = ""
+	expected.ASCII = true
+
+	if diff := pretty.Compare(expected, settings.Sanitise); diff != "" {
+		t.Errorf("Sanitise unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestLoadSettings_Sidecars(t *testing.T) {
+
+	settings, err := LoadSettings(strings.NewReader(`{"sidecars": {"nfo": "rename", "sample": "delete"}}`))
+	if err != nil {
+		t.Fatalf("unexpected error loading settings: %s", err.Error())
+	}
+
+	//rules not given keep their defaults
+	expected := filing.DefaultRules()
+	expected[filing.SidecarNFO] = filing.ActionRename
+	expected[filing.SidecarSample] = filing.ActionDelete
+
+	if diff := pretty.Compare(expected, settings.Sidecars); diff != "" {
+		t.Errorf("Sidecars unexpected diff (-want +got):\n%s", diff)
+	}
+
+	for _, config := range []string{`{"sidecars": {"nfo": "burn"}}`, `{"sidecars": {"readme": "delete"}}`} {
+		if _, err := LoadSettings(strings.NewReader(config)); err == nil {
+			t.Errorf("expected error loading %s, got none", config)
+		}
+	}
+}
+
+func TestLoadSettings_Order(t *testing.T) {
+
+	settings, err := LoadSettings(strings.NewReader(`{"order": {"default": "dvd", "shows": {"TMDB": {"615": "5b11ba820e0a265847002c6e"}, "TVDB": {"73871": "aired"}}}}`))
+	if err != nil {
+		t.Fatalf("unexpected error loading settings: %s", err.Error())
+	}
+
+	expected := &Order{
+		Default: dbs.DVDOrder,
+		Shows: map[string]map[int]dbs.Order{
+			"TMDB": {615: "5b11ba820e0a265847002c6e"},
+			"TVDB": {73871: dbs.AiredOrder},
+		},
+	}
+
+	if diff := pretty.Compare(expected, settings.Order); diff != "" {
+		t.Errorf("Order unexpected diff (-want +got):\n%s", diff)
+	}
+
+	//episode groups are only TMDB's
+	for _, config := range []string{`{"order": {"default": "production"}}`, `{"order": {"shows": {"TVDB": {"73871": "5b11ba820e0a265847002c6e"}}}}`, `{"order": {"shows": {"IMDB": {"1": "dvd"}}}}`} {
+		if _, err := LoadSettings(strings.NewReader(config)); err == nil {
+			t.Errorf("expected error loading %s, got none", config)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"sort"
+
+	"github.com/rustedturnip/media-mapper/types"
+)
+
+const (
+	//season of files with episodes numbered from the show's first episode
+	//rather than within their season (e.g. anime), which are mapped to their
+	//actual season when matched
+	absoluteSeason = -1
+)
+
+//returns the series and episode of show with the absolute number. If the
+//provider doesn't number episodes absolutely they're counted in order from
+//the first episode of the first series, specials excluded.
+func absoluteEpisode(show *types.TV, number int) (*types.Series, *types.Episode) {
+
+	var seasons []int
+	for season := range show.Series {
+		if season > 0 {
+			seasons = append(seasons, season)
+		}
+	}
+	sort.Ints(seasons)
+
+	numbered := false
+	for _, season := range seasons {
+		series := show.Series[season]
+		for _, episode := range series.Episodes {
+			if episode.AbsoluteNumber == number {
+				return series, episode
+			}
+			numbered = numbered || episode.AbsoluteNumber != 0
+		}
+	}
+
+	if numbered {
+		return nil, nil
+	}
+
+	count := 0
+	for _, season := range seasons {
+		series := show.Series[season]
+
+		var episodes []int
+		for episode := range series.Episodes {
+			episodes = append(episodes, episode)
+		}
+		sort.Ints(episodes)
+
+		if count+len(episodes) < number {
+			count += len(episodes)
+			continue
+		}
+
+		return series, series.Episodes[episodes[number-count-1]]
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,129 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/rustedturnip/media-mapper/types"
+)
+
+//season and episode numbers of an episode found in a show
+type testEpisode struct {
+	Season  int
+	Episode int
+}
+
+//returns the numbers of the episode found, nil if it wasn't
+func found(series *types.Series, episode *types.Episode) *testEpisode {
+
+	if series == nil || episode == nil {
+		return nil
+	}
+
+	return &testEpisode{Season: series.Number, Episode: episode.Number}
+}
+
+//builds a show released in the year (if not 0) with the episodes of each
+//series, numbered from 1 in order
+func testShow(title string, released int, series map[int][]*types.Episode) *types.TV {
+
+	show := types.NewTV()
+	show.Title = title
+	if released != 0 {
+		show.ReleaseDate = time.Date(released, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	for number, episodes := range series {
+		s := types.NewSeries()
+		s.Number = number
+		for i, episode := range episodes {
+			episode.Number = i + 1
+			s.Episodes[episode.Number] = episode
+		}
+
+		show.Series[number] = s
+		if number > 0 {
+			show.SeriesCount++
+		}
+	}
+
+	return show
+}
+
+//returns count episodes, numbered absolutely from first (or not, if 0)
+func testEpisodes(count, first int) []*types.Episode {
+
+	episodes := make([]*types.Episode, count)
+	for i := range episodes {
+		episodes[i] = &types.Episode{}
+		if first != 0 {
+			episodes[i].AbsoluteNumber = first + i
+		}
+	}
+
+	return episodes
+}
+
+func TestAbsoluteEpisode(t *testing.T) {
+
+	var tests = []struct {
+		name     string
+		series   map[int][]*types.Episode
+		number   int
+		expected *testEpisode
+	}{
+		{
+			name: "Numbered by Provider",
+			series: map[int][]*types.Episode{
+				1: testEpisodes(3, 1),
+				2: testEpisodes(3, 4),
+			},
+			number:   5,
+			expected: &testEpisode{Season: 2, Episode: 2},
+		},
+		{
+			name: "Numbered by Provider, Not Found",
+			series: map[int][]*types.Episode{
+				1: testEpisodes(3, 1),
+				2: testEpisodes(3, 4),
+			},
+			number: 7,
+		},
+		{
+			name: "Counted",
+			series: map[int][]*types.Episode{
+				0: testEpisodes(2, 0), //specials are excluded
+				1: testEpisodes(6, 0),
+				2: testEpisodes(4, 0),
+			},
+			number:   10,
+			expected: &testEpisode{Season: 2, Episode: 4},
+		},
+		{
+			name: "Counted First of Series",
+			series: map[int][]*types.Episode{
+				1: testEpisodes(6, 0),
+				2: testEpisodes(4, 0),
+			},
+			number:   7,
+			expected: &testEpisode{Season: 2, Episode: 1},
+		},
+		{
+			name: "Counted Beyond Last Episode",
+			series: map[int][]*types.Episode{
+				1: testEpisodes(6, 0),
+				2: testEpisodes(4, 0),
+			},
+			number: 11,
+		},
+	}
+
+	for _, test := range tests {
+		show := testShow("Show", 0, test.series)
+
+		if diff := pretty.Compare(test.expected, found(absoluteEpisode(show, test.number))); diff != "" {
+			t.Errorf("%s unexpected diff (-want +got):\n%s", test.name, diff)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package controller
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rustedturnip/media-mapper/ranking"
+)
+
+const (
+	ambiguityMargin = 0.15 //candidates scoring within this of the best are offered to the user
+	maxCandidates   = 5
+	overviewLength  = 80
+)
+
+//candidate is a database entry, movie or tv, as presented to the user
+type candidate struct {
+	id         int
+	title      string
+	year       int
+	overview   string
+	confidence float64
+}
+
+func (c *candidate) String() string {
+	if c.year == 0 {
+		return c.title
+	}
+	return fmt.Sprintf("%s (%d)", c.title, c.year)
+}
+
+func movieCandidates(matches []*ranking.MovieMatch) []*candidate {
+
+	candidates := make([]*candidate, 0, len(matches))
+	for _, match := range matches {
+		candidates = append(candidates, &candidate{
+			id:         match.Movie.ID,
+			title:      match.Movie.Title,
+			year:       match.Movie.ReleaseDate.Year(),
+			overview:   match.Movie.Overview,
+			confidence: match.Confidence,
+		})
+	}
+
+	return candidates
+}
+
+func tvCandidates(matches []*ranking.TVMatch) []*candidate {
+
+	candidates := make([]*candidate, 0, len(matches))
+	for _, match := range matches {
+		year := 0
+		if !match.TV.ReleaseDate.IsZero() {
+			year = match.TV.ReleaseDate.Year()
+		}
+
+		candidates = append(candidates, &candidate{
+			id:         match.TV.ID,
+			title:      match.TV.Title,
+			year:       year,
+			overview:   match.TV.Overview,
+			confidence: match.Confidence,
+		})
+	}
+
+	return candidates
+}
+
+//choose picks which of the ranked candidates (best first) to use for file,
+//returning its index and confidence, or -1 if none should be used. The user
+//is asked when several candidates are plausible, and can choose to have
+//their answer reused for the rest of the directory.
+func (w *Worker) choose(dir, file, query string, candidates []*candidate) (int, float64) {
+
+	if len(candidates) == 0 {
+		return -1, 0
+	}
+
+	if w.streamline {
+		return 0, candidates[0].confidence
+	}
+
+	//only one file can prompt the user at a time, and a file waiting for
+	//another may then reuse its choice
+	w.promptMu.Lock()
+	defer w.promptMu.Unlock()
+
+	//reuse choice made for an earlier file in this directory
+	if id, ok := w.choices[dir][query]; ok {
+		for i, c := range candidates {
+			if c.id == id {
+				return i, 1
+			}
+		}
+	}
+
+	plausible := w.plausible(candidates)
+	if len(plausible) < 2 {
+		return 0, candidates[0].confidence
+	}
+
+	fmt.Printf("\nMultiple matches for %q:\n", file)
+	for i, c := range plausible {
+		fmt.Printf("  %d) %s [ID %d] - %.2f\n", i+1, c, c.id, c.confidence)
+		if c.overview != "" {
+			fmt.Printf("     %s\n", snippet(c.overview, overviewLength))
+		}
+	}
+	fmt.Println("  0) none of these")
+
+	choice := -1
+	for choice < 0 {
+		text := w.prompt(fmt.Sprintf("Choose a match (0-%d, default 1): ", len(plausible)))
+		if text == "" {
+			choice = 1
+			break
+		}
+
+		if n, err := strconv.Atoi(text); err == nil && n >= 0 && n <= len(plausible) {
+			choice = n
+		}
+	}
+
+	if choice == 0 {
+		return -1, 0
+	}
+
+	chosen := plausible[choice-1]
+	if len(w.filer.GetFiles()[dir]) > 1 && w.confirm(fmt.Sprintf("Use %s for the rest of %s? (y/n): ", chosen, dir)) {
+		if _, ok := w.choices[dir]; !ok {
+			w.choices[dir] = make(map[string]int)
+		}
+		w.choices[dir][query] = chosen.id
+	}
+
+	//user has confirmed the match so it's no longer a guess
+	return choice - 1, 1
+}
+
+//returns the leading candidates that score close enough to the best to be worth offering
+func (w *Worker) plausible(candidates []*candidate) []*candidate {
+
+	best := candidates[0].confidence
+
+	var plausible []*candidate
+	for _, c := range candidates {
+		if len(plausible) == maxCandidates || c.confidence < best-ambiguityMargin || c.confidence < w.minConfidence {
+			break
+		}
+		plausible = append(plausible, c)
+	}
+
+	return plausible
+}
+
+//shortens text to at most length runes, breaking on a word where possible
+func snippet(text string, length int) string {
+
+	text = strings.Join(strings.Fields(text), " ")
+
+	runes := []rune(text)
+	if len(runes) <= length {
+		return text
+	}
+
+	//a word ending at the cut is kept whole
+	cut := string(runes[:length])
+	if i := strings.LastIndex(cut, " "); i > 0 && runes[length] != ' ' {
+		cut = cut[:i]
+	}
+
+	return cut + "..."
+}
@@ -0,0 +1,109 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestWorker_plausible(t *testing.T) {
+
+	var tests = []struct {
+		name          string
+		minConfidence float64
+		confidences   []float64 //of candidates, best first
+		expected      []int     //IDs (indexes) of plausible candidates
+	}{
+		{
+			name:        "Single",
+			confidences: []float64{0.9},
+			expected:    []int{0},
+		},
+		{
+			name:        "Within Margin",
+			confidences: []float64{0.9, 0.8, 0.7},
+			expected:    []int{0, 1},
+		},
+		{
+			name:        "Capped",
+			confidences: []float64{0.9, 0.9, 0.9, 0.9, 0.9, 0.9, 0.9},
+			expected:    []int{0, 1, 2, 3, 4},
+		},
+		{
+			name:          "Below Min Confidence",
+			minConfidence: 0.5,
+			confidences:   []float64{0.6, 0.55, 0.48},
+			expected:      []int{0, 1},
+		},
+		{
+			name:          "Best Below Min Confidence",
+			minConfidence: 0.5,
+			confidences:   []float64{0.4, 0.35},
+		},
+	}
+
+	for _, test := range tests {
+		w := &Worker{minConfidence: test.minConfidence}
+
+		var candidates []*candidate
+		for i, confidence := range test.confidences {
+			candidates = append(candidates, &candidate{id: i, confidence: confidence})
+		}
+
+		var actual []int
+		for _, c := range w.plausible(candidates) {
+			actual = append(actual, c.id)
+		}
+
+		if diff := pretty.Compare(test.expected, actual); diff != "" {
+			t.Errorf("%s unexpected diff (-want +got):\n%s", test.name, diff)
+		}
+	}
+}
+
+func TestSnippet(t *testing.T) {
+
+	var tests = []struct {
+		name     string
+		input    string
+		length   int
+		expected string
+	}{
+		{
+			name:     "Short",
+			input:    "A short  overview.",
+			length:   20,
+			expected: "A short overview.",
+		},
+		{
+			name:     "Word Boundary",
+			input:    "The hopes and dreams of four ambitious people",
+			length:   18,
+			expected: "The hopes and...",
+		},
+		{
+			name:     "Word Ending at Cut",
+			input:    "The hopes and dreams of four ambitious people",
+			length:   20,
+			expected: "The hopes and dreams...",
+		},
+		{
+			name:     "Single Word",
+			input:    "Supercalifragilisticexpialidocious",
+			length:   10,
+			expected: "Supercalif...",
+		},
+		{
+			name:     "Runes",
+			input:    "Amélie découvre un secret",
+			length:   10,
+			expected: "Amélie...",
+		},
+	}
+
+	for _, test := range tests {
+		if actual := snippet(test.input, test.length); actual != test.expected {
+			t.Errorf("%s expected %q, got %q", test.name, test.expected, actual)
+		}
+	}
+}
@@ -3,71 +3,228 @@ package controller
 import (
 	"bufio"
 	"fmt"
+	"log"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 
 	colour "github.com/fatih/color"
 	ptn "github.com/middelink/go-parse-torrent-name"
+	"github.com/rustedturnip/media-mapper/artwork"
 	"github.com/rustedturnip/media-mapper/dbs"
 	"github.com/rustedturnip/media-mapper/filing"
+	"github.com/rustedturnip/media-mapper/naming"
+	"github.com/rustedturnip/media-mapper/ranking"
+	"github.com/rustedturnip/media-mapper/types"
 )
 
 const (
-	parseErr = "%s: failed to parse - %s"
+	parseErr      = "%s: failed to parse - %s"
+	confidenceErr = "%s: best match %q has low confidence (%.2f)"
+	namingErr     = "%s: failed to name - %s"
 
-	tvTitleFmt    = "%s - %dx%d - %s"
-	movieTitleFmt = "%s (%d)"
+	multiEpisodeTitleSep = " + "
+	airDateFormat        = "2006-01-02"
 )
 
+//Options configure how a Worker matches and renames files
+type Options struct {
+	Streamline    bool    //run without user input, making changes automatically
+	MinConfidence float64 //matches scoring below this are not renamed
+	Workers       int     //number of files looked up concurrently
+
+	Provider  string            //name of the database, available to naming templates
+	Scheme    *naming.Scheme    //defaults to naming.DefaultScheme
+	Sanitiser *naming.Sanitiser //defaults to naming.NewSanitiser
+
+	Order      dbs.Order         //order episodes are numbered in, defaults to dbs.AiredOrder
+	ShowOrders map[int]dbs.Order //orders of individual shows (by ID in the database), overriding Order
+
+	Organise    bool   //move files into directories named by the scheme
+	Destination string //directory files are organised into, defaults to the filer's root
+
+	Conflicts filing.Strategy //resolves conflicting renames when streamlined, and the default otherwise
+	Journal   *filing.Journal //records renames so they can be undone, nil to not record them
+
+	NFO     bool                //write Kodi/Jellyfin nfo files for the renamed files
+	Artwork *artwork.Downloader //downloads posters and fanart for the renamed files, nil to not download them
+}
+
 type Worker struct {
-	database   dbs.Database
-	filer      *filing.Filer
-	streamline bool
-	errs       []error
+	database      dbs.Database
+	filer         *filing.Filer
+	streamline    bool
+	minConfidence float64
+	workers       int
+	provider      string
+	scheme        *naming.Scheme
+	sanitiser     *naming.Sanitiser
+	order         dbs.Order
+	showOrders    map[int]dbs.Order
+	organise      bool
+	destination   string
+	conflicts     filing.Strategy
+	journal       *filing.Journal
+	nfo           bool
+	artwork       *artwork.Downloader
+	errs          []error
+	results       map[*filing.File]*result //set by lookup
+	fileErrs      map[*filing.File]error   //errors of errs, by file
+
+	promptMu sync.Mutex                //held while asking the user to choose between candidates
+	reader   *bufio.Reader             //user input, unused when streamlined
+	choices  map[string]map[string]int //key: directory, query title; value: chosen candidate ID
 }
 
-func New(database dbs.Database, filer *filing.Filer, streamline bool) *Worker {
+func New(database dbs.Database, filer *filing.Filer, options Options) *Worker {
+
+	workers := options.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	scheme := options.Scheme
+	if scheme == nil {
+		scheme = naming.Schemes[naming.DefaultScheme]
+	}
+
+	sanitiser := options.Sanitiser
+	if sanitiser == nil {
+		sanitiser = naming.NewSanitiser()
+	}
+
+	destination := options.Destination
+	if destination == "" {
+		destination = filer.GetRoot()
+	}
+
+	conflicts := options.Conflicts
+	if conflicts == "" {
+		conflicts = filing.StrategySkip
+	}
+
+	order := options.Order
+	if order == "" {
+		order = dbs.AiredOrder
+	}
+
 	return &Worker{
-		database:   database,
-		filer:      filer,
-		streamline: streamline,
+		database:      database,
+		filer:         filer,
+		streamline:    options.Streamline,
+		minConfidence: options.MinConfidence,
+		workers:       workers,
+		provider:      options.Provider,
+		scheme:        scheme,
+		sanitiser:     sanitiser,
+		order:         order,
+		showOrders:    options.ShowOrders,
+		organise:      options.Organise,
+		destination:   destination,
+		conflicts:     conflicts,
+		journal:       options.Journal,
+		nfo:           options.NFO,
+		artwork:       options.Artwork,
+		reader:        bufio.NewReader(os.Stdin),
+		choices:       make(map[string]map[string]int),
+		results:       make(map[*filing.File]*result),
+		fileErrs:      make(map[*filing.File]error),
 	}
 }
 
+//result is the database entry matched to a file, either a movie or an
+//episode of a show
+type result struct {
+	id         int
+	confidence float64
+
+	movie    *types.Movie
+	show     *types.TV
+	series   *types.Series
+	episodes []*types.Episode //more than one for multi-episode files
+}
+
+//Do looks up and renames the files
 func (w *Worker) Do() {
 
-	for _, files := range w.filer.GetFiles() {
-		for _, file := range files {
-			info, err := ptn.Parse(file.Name)
-			if err != nil {
-				fName := fmt.Sprintf("%s.%s", file.Name, file.Ext)
-				w.errs = append(w.errs, fmt.Errorf(parseErr, fName, file))
+	w.lookupAll()
+	w.rename("Match errors")
+}
+
+//Apply renames the files, which have already been given their new names
+//e.g. from a plan, reporting errs alongside the changes
+func (w *Worker) Apply(errs []error) {
+
+	w.errs = errs
+	w.rename("Plan errors")
+}
+
+//Plan looks up the files and returns the renames they'd be given, without
+//renaming them
+func (w *Worker) Plan() *filing.Plan {
+
+	w.lookupAll()
+
+	plan := filing.NewPlan(w.filer.GetRoot())
+	files := w.filer.GetFiles()
+	for _, dir := range w.filer.GetDirs() {
+		for _, file := range files[dir] {
+			item := filing.NewPlanItem(dir, file)
+
+			if result, ok := w.results[file]; ok {
+				item.Provider = w.provider
+				item.ID = result.id
+				item.Confidence = result.confidence
 			}
 
-			file.NewName = w.getName(info)
+			if err, ok := w.fileErrs[file]; ok {
+				item.Error = err.Error()
+			}
+
+			plan.Items = append(plan.Items, item)
 		}
 	}
 
+	return plan
+}
+
+//shows the changes and errors, resolves conflicts and, once confirmed,
+//renames the files and records the renames in the journal
+func (w *Worker) rename(errsTitle string) {
+
 	//print diff
 	if !w.streamline {
 		w.filer.PrintBatchDiff()
 	}
 
 	//display failed files
-	if len(w.errs) > 1 {
-		fmt.Println("\nMatch errors:")
+	if len(w.errs) > 0 {
+		fmt.Printf("\n%s:\n", errsTitle)
 		for _, err := range w.errs {
 			colour.Yellow("! %s", err.Error())
 		}
 	}
 
+	//ensure no file is overwritten unintentionally
+	if w.resolveConflicts() && !w.streamline {
+		w.filer.PrintBatchDiff()
+	}
+
 	//user input, proceed?
 	if !w.streamline {
-		reader := bufio.NewReader(os.Stdin)
-		fmt.Print("Proceed with changes? (y/n): ")
-		text, _ := reader.ReadString('\n')
+		switch strings.ToLower(w.prompt("Proceed with changes? (y/n, or r to review each change): ")) {
+		case "y":
+		case "r":
+			w.review()
+			w.resolveConflicts()
+			w.filer.PrintBatchDiff()
 
-		if strings.ToLower(strings.Trim(text, "\r\n")) != "y" {
+			if !w.confirm("Proceed with reviewed changes? (y/n): ") {
+				fmt.Println("Cancelling...")
+				return
+			}
+		default:
 			fmt.Println("Cancelling...")
 			return
 		}
@@ -77,39 +234,369 @@ func (w *Worker) Do() {
 	if !w.streamline {
 		fmt.Println("Renaming files...")
 	}
-	w.filer.RenameBatch()
+	run := w.filer.RenameBatch()
+
+	if w.nfo {
+		w.writeNFOs(run)
+	}
+
+	if w.artwork != nil {
+		w.downloadArtwork(run)
+	}
+
+	//record run so it can be undone
+	if w.journal != nil && (len(run.Renamed) > 0 || len(run.Written) > 0) {
+		if err := w.journal.Record(run); err != nil {
+			log.Println(fmt.Sprintf("Failed recording renames in journal with error: %s", err.Error()))
+			return
+		}
+		fmt.Printf("Renamed %d and wrote %d files, to undo run: media-mapper undo %s\n", len(run.Renamed), len(run.Written), run.ID)
+	}
 }
 
-func (w *Worker) getName(info *ptn.TorrentInfo) string {
+//resolves renames that would overwrite each other or existing files, using
+//the configured strategy when streamlined or otherwise the one the user
+//chooses. Returns true if there were conflicts.
+func (w *Worker) resolveConflicts() bool {
+
+	conflicts := w.filer.FindConflicts()
+	if len(conflicts) == 0 {
+		return false
+	}
+
+	strategy := w.conflicts
+	if !w.streamline {
+		fmt.Printf("\n%d conflicting renames found.\n", len(conflicts))
+
+		strategies := map[string]filing.Strategy{
+			"":  w.conflicts,
+			"s": filing.StrategySkip,
+			"u": filing.StrategySuffix,
+			"q": filing.StrategyQuality,
+			"r": filing.StrategyReplace,
+		}
+
+		for {
+			msg := fmt.Sprintf("Resolve by [s]kipping, s[u]ffixing, keeping the best [q]uality or [r]eplacing (default %s): ", w.conflicts)
+
+			var ok bool
+			if strategy, ok = strategies[strings.ToLower(w.prompt(msg))]; ok {
+				break
+			}
+		}
+	}
+
+	w.filer.ResolveConflicts(conflicts, strategy, quality)
+
+	if w.streamline {
+		fmt.Printf("Resolved %d conflicting renames (%s)\n", len(conflicts), strategy)
+	}
+
+	return true
+}
+
+//looks up the new names of all files using a pool of workers. Errors are
+//collected per file and recorded afterwards so their order is deterministic.
+func (w *Worker) lookupAll() {
+
+	type job struct {
+		dir  string
+		file *filing.File
+	}
+
+	var jobs []job
+	files := w.filer.GetFiles()
+	for _, dir := range w.filer.GetDirs() {
+		for _, file := range files[dir] {
+			jobs = append(jobs, job{dir: dir, file: file})
+		}
+	}
+
+	results := make([]*result, len(jobs))
+	errs := make([]error, len(jobs))
+	queue := make(chan int)
+
+	var wg sync.WaitGroup
+	for i := 0; i < w.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				results[i], errs[i] = w.lookup(jobs[i].dir, jobs[i].file)
+			}
+		}()
+	}
+
+	for i := range jobs {
+		queue <- i
+	}
+	close(queue)
+	wg.Wait()
+
+	for i, err := range errs {
+		if results[i] != nil {
+			w.results[jobs[i].file] = results[i]
+		}
+
+		if err != nil {
+			w.errs = append(w.errs, err)
+			w.fileErrs[jobs[i].file] = err
+		}
+	}
+}
+
+//finds and sets the new name of a single file, returning the database
+//entry it was matched to, if any
+func (w *Worker) lookup(dir string, file *filing.File) (*result, error) {
+
+	info, err := parseFile(file)
+	if err != nil {
+		return nil, fmt.Errorf(parseErr, file.GetName(), err)
+	}
+
+	//files tagged with their IDs are fetched directly, without searching
+	fields, result := w.matchID(dir, file, info)
+	if fields == nil {
+		fields, result = w.match(dir, file, info)
+	}
+
+	//names taken for unnumbered specials or episodes numbered absolutely may
+	//be movies, e.g. "The.Extra.Man.2010" or "[Group] Movie 2 [1080p]", so
+	//are also matched as parsed plainly unless confidently matched, keeping
+	//the better match
+	if (info.Episode == unnumberedEpisode || info.Season == absoluteSeason) && (fields == nil || result.confidence < w.minConfidence) {
+		if plain, err := ptn.Parse(file.Name); err == nil {
+			plainFields, plainResult := w.match(dir, file, plain)
+			if plainFields != nil && (fields == nil || plainResult.confidence > result.confidence) {
+				info, fields, result = plain, plainFields, plainResult
+			}
+		}
+	}
+	if fields == nil {
+		return nil, nil
+	}
+
+	name, newDir, err := w.newName(file, info, fields)
+	if err != nil {
+		return result, fmt.Errorf(namingErr, file.GetName(), err)
+	}
+
+	if result.confidence < w.minConfidence {
+		return result, fmt.Errorf(confidenceErr, file.GetName(), name, result.confidence)
+	}
+
+	file.NewName = name
+	file.NewDir = newDir
+	return result, nil
+}
+
+//finds the database entry for the media described by info, returning the
+//naming fields of the entry and the entry, along with the confidence (0 to 1)
+//that it's the right one. Both are nil if no entry is found.
+func (w *Worker) match(dir string, file *filing.File, info *ptn.TorrentInfo) (*naming.Fields, *result) {
+
+	query := ranking.Query{
+		Title:   info.Title,
+		Year:    info.Year,
+		Season:  info.Season,
+		Episode: info.Episode,
+	}
 
 	switch info.Episode {
 	case 0: //Movie
-		//TODO - better movie selection
-		results := w.database.SearchMovies(info.Title)
-		if len(results) == 0 {
-			return ""
+		matches := ranking.Movies(query, w.database.SearchMovies(info.Title, info.Year))
+		i, confidence := w.choose(dir, file.GetName(), info.Title, movieCandidates(matches))
+		if i < 0 {
+			return nil, nil
 		}
-		movie := results[0]
-		return fmt.Sprintf(movieTitleFmt, movie.Title, movie.ReleaseDate.Year())
+
+		return w.movieMatch(info, w.movieDetails(matches[i].Movie), confidence)
 
 	default: //Episode of TV Series
-		//TODO - better movie selection
-		results := w.database.SearchTV(info.Title)
-		if len(results) == 0 {
-			return ""
+		//candidates have no series, so the file's series is fetched for
+		//only the candidates the ranking checks for the episode
+		fetched := make(map[int]*types.TV)
+		hasEpisode := func(candidate *types.TV) bool {
+			show := w.getTV(candidate.ID, info)
+			if show == nil {
+				return false
+			}
+			fetched[candidate.ID] = show
+
+			_, episode := w.findEpisode(file, show, info)
+			return episode != nil
+		}
+
+		shows := w.searchTV(info)
+		query.Title = info.Title
+
+		matches := ranking.TV(query, shows, hasEpisode)
+		i, confidence := w.choose(dir, file.GetName(), info.Title, tvCandidates(matches))
+		if i < 0 {
+			return nil, nil
+		}
+
+		show, ok := fetched[matches[i].TV.ID]
+		if !ok {
+			if show = w.getTV(matches[i].TV.ID, info); show == nil {
+				return nil, nil
+			}
 		}
-		show := results[0]
 
-		if _, ok := show.Series[info.Season]; !ok {
-			return "" //can't find series
+		return w.episodeMatch(file, info, show, confidence)
+	}
+}
+
+//returns the movie with its details, such as its external IDs, which search
+//results may lack
+func (w *Worker) movieDetails(movie *types.Movie) *types.Movie {
+
+	if details := w.database.GetMovie(movie.ID); details != nil {
+		return details
+	}
+
+	return movie
+}
+
+//returns the naming fields and result of the movie matched with the file
+//described by info
+func (w *Worker) movieMatch(info *ptn.TorrentInfo, movie *types.Movie, confidence float64) (*naming.Fields, *result) {
+
+	fields := w.fields(info)
+	fields.Title = movie.Title
+	fields.Year = movie.ReleaseDate.Year()
+	fields.ID = movie.ID
+	w.setIDs(fields, movie.ID, movie.ExternalIDs)
+
+	return fields, &result{id: movie.ID, confidence: confidence, movie: movie}
+}
+
+//returns the naming fields and result of the episode of show matched with
+//the file described by info, or each of its episodes if it's a multi-episode
+//file. Both are nil if show doesn't have the (first) episode.
+func (w *Worker) episodeMatch(file *filing.File, info *ptn.TorrentInfo, show *types.TV, confidence float64) (*naming.Fields, *result) {
+
+	series, episode := w.findEpisode(file, show, info)
+	if episode == nil {
+		return nil, nil //can't find episode
+	}
+
+	//episodes of multi-episode files missing from the series (e.g. a two
+	//parter the database has as one episode) are left out of the name
+	episodes := []*types.Episode{episode}
+	for _, number := range w.episodeNumbers(file, info)[1:] {
+		if episode, ok := series.Episodes[number]; ok {
+			episodes = append(episodes, episode)
 		}
-		series := show.Series[info.Season]
+	}
+
+	fields := w.fields(info)
+	fields.Title = show.Title
+	fields.Season = series.Number
+	fields.Episode = episode.Number
+	fields.AbsoluteEpisode = episode.AbsoluteNumber
+	fields.EpisodeTitle = episode.Title
+	fields.ID = show.ID
+	w.setIDs(fields, show.ID, show.ExternalIDs)
+	if !show.ReleaseDate.IsZero() {
+		fields.Year = show.ReleaseDate.Year()
+	}
+	if !episode.AirDate.IsZero() {
+		fields.AirDate = episode.AirDate.Format(airDateFormat)
+	}
 
-		if _, ok := series.Episodes[info.Episode]; !ok {
-			return "" //can't find episode in series
+	if len(episodes) > 1 {
+		for _, episode := range episodes {
+			fields.Episodes = append(fields.Episodes, episode.Number)
+			fields.EpisodeTitles = append(fields.EpisodeTitles, episode.Title)
 		}
-		episode := series.Episodes[info.Episode]
+		fields.LastEpisode = fields.Episodes[len(fields.Episodes)-1]
+		fields.EpisodeTitle = strings.Join(fields.EpisodeTitles, multiEpisodeTitleSep)
+	}
+
+	return fields, &result{id: show.ID, confidence: confidence, show: show, series: series, episodes: episodes}
+}
 
-		return fmt.Sprintf(tvTitleFmt, show.Title, series.Number, episode.Number, episode.Title)
+//returns the numbers of the episodes in the file described by info, more
+//than one if it's a multi-episode file (e.g. S01E01E02)
+func (w *Worker) episodeNumbers(file *filing.File, info *ptn.TorrentInfo) []int {
+
+	season, episodes := naming.ParseEpisodes(file.Name)
+	if len(episodes) == 0 || season != info.Season || episodes[0] != info.Episode {
+		return []int{info.Episode}
 	}
+
+	return episodes
+}
+
+//returns the new name of the file described by info and, when organising,
+//the directory it's moved to. Both are sanitised so they're safe to use.
+func (w *Worker) newName(file *filing.File, info *ptn.TorrentInfo, fields *naming.Fields) (string, string, error) {
+
+	name, dir := w.scheme.Movie, w.scheme.MovieDir
+	if info.Episode != 0 {
+		name, dir = w.scheme.TV, w.scheme.TVDir
+	}
+
+	fields = w.sanitiser.Fields(fields)
+
+	newName, err := name(fields)
+	if err != nil {
+		return "", "", err
+	}
+
+	if newName = w.sanitiser.Name(newName, file.Ext); newName == "" {
+		return "", "", fmt.Errorf("empty name")
+	}
+
+	if !w.organise {
+		return newName, "", nil
+	}
+
+	newDir, err := dir(fields)
+	if err != nil {
+		return "", "", err
+	}
+
+	if newDir = w.sanitiser.Dir(newDir); newDir == "" {
+		return "", "", fmt.Errorf("empty directory")
+	}
+
+	return newName, filepath.Join(w.destination, newDir), nil
+}
+
+//returns the naming fields known before a match is found
+func (w *Worker) fields(info *ptn.TorrentInfo) *naming.Fields {
+	return &naming.Fields{
+		Provider:   w.provider,
+		Resolution: info.Resolution,
+		Quality:    info.Quality,
+		Codec:      info.Codec,
+		Audio:      info.Audio,
+		Group:      info.Group,
+	}
+}
+
+//returns the order the episodes of the show with the ID are numbered in
+func (w *Worker) episodeOrder(id int) dbs.Order {
+
+	if order, ok := w.showOrders[id]; ok {
+		return order
+	}
+
+	return w.order
+}
+
+//prints msg and returns the user's trimmed response
+func (w *Worker) prompt(msg string) string {
+
+	fmt.Print(msg)
+	text, _ := w.reader.ReadString('\n')
+
+	return strings.Trim(text, " \r\n")
+}
+
+//prints a yes/no question and returns true if the user answers yes
+func (w *Worker) confirm(msg string) bool {
+	return strings.ToLower(w.prompt(msg)) == "y"
 }
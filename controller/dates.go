@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"sort"
+	"time"
+
+	"github.com/rustedturnip/media-mapper/types"
+)
+
+const (
+	//season of files named by their air date rather than their season and
+	//episode (e.g. daily shows), whose episode is the date as YYYYMMDD, which
+	//are mapped to the episode that aired on the date when matched
+	datedSeason = -2
+)
+
+//returns the date as the episode of a file with the datedSeason, e.g.
+//20201023 for 23rd October 2020
+func dateEpisode(date time.Time) int {
+	return date.Year()*10000 + int(date.Month())*100 + date.Day()
+}
+
+//returns the date of the episode of a file with the datedSeason
+func episodeDate(episode int) time.Time {
+	return time.Date(episode/10000, time.Month(episode/100%100), episode%100, 0, 0, 0, 0, time.UTC)
+}
+
+//returns the series and episode of show that aired on the date, the first
+//if several did. Both are nil if no episode aired on the date.
+func datedEpisode(show *types.TV, date time.Time) (*types.Series, *types.Episode) {
+
+	var seasons []int
+	for season := range show.Series {
+		seasons = append(seasons, season)
+	}
+	//specials (season 0) are checked last, as regular episodes may air on the same date
+	sort.Slice(seasons, func(i, j int) bool {
+		return seasons[j] == 0 || (seasons[i] != 0 && seasons[i] < seasons[j])
+	})
+
+	for _, season := range seasons {
+		series := show.Series[season]
+
+		var episodes []int
+		for episode := range series.Episodes {
+			episodes = append(episodes, episode)
+		}
+		sort.Ints(episodes)
+
+		for _, number := range episodes {
+			episode := series.Episodes[number]
+
+			y, m, d := episode.AirDate.Date()
+			if y == date.Year() && m == date.Month() && d == date.Day() {
+				return series, episode
+			}
+		}
+	}
+
+	return nil, nil
+}
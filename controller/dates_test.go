@@ -0,0 +1,128 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	ptn "github.com/middelink/go-parse-torrent-name"
+	"github.com/rustedturnip/media-mapper/filing"
+	"github.com/rustedturnip/media-mapper/types"
+)
+
+//returns an episode with the title that aired on the date, e.g. 2020-10-23
+func aired(title, date string) *types.Episode {
+
+	airDate, _ := time.Parse(airDateFormat, date)
+
+	return &types.Episode{Title: title, AirDate: airDate}
+}
+
+func TestDatedEpisode(t *testing.T) {
+
+	series := func() map[int][]*types.Episode {
+		return map[int][]*types.Episode{
+			0: {aired("Election Special", "2020-11-03"), aired("Anniversary", "2021-01-04")},
+			1: {aired("A", "2020-10-05"), aired("B", "2020-10-06"), aired("C", "2020-10-06")},
+			2: {aired("D", "2021-01-04"), aired("E", "2021-01-05")},
+		}
+	}
+
+	var tests = []struct {
+		name     string
+		date     string
+		expected *testEpisode
+	}{
+		{
+			name:     "Episode",
+			date:     "2021-01-05",
+			expected: &testEpisode{Season: 2, Episode: 2},
+		},
+		{
+			name:     "First of Several",
+			date:     "2020-10-06",
+			expected: &testEpisode{Season: 1, Episode: 2},
+		},
+		{
+			name:     "Special",
+			date:     "2020-11-03",
+			expected: &testEpisode{Season: 0, Episode: 1},
+		},
+		{
+			name:     "Episode Before Special",
+			date:     "2021-01-04",
+			expected: &testEpisode{Season: 2, Episode: 1},
+		},
+		{
+			name: "Not Aired",
+			date: "2020-10-07",
+		},
+	}
+
+	for _, test := range tests {
+		show := testShow("Show", 0, series())
+		date, _ := time.Parse(airDateFormat, test.date)
+
+		if diff := pretty.Compare(test.expected, found(datedEpisode(show, date))); diff != "" {
+			t.Errorf("%s unexpected diff (-want +got):\n%s", test.name, diff)
+		}
+	}
+}
+
+func TestDateEpisode(t *testing.T) {
+
+	date := time.Date(2020, 10, 23, 0, 0, 0, 0, time.UTC)
+
+	if episode := dateEpisode(date); episode != 20201023 {
+		t.Errorf("expected episode 20201023, got %d", episode)
+	}
+
+	if actual := episodeDate(dateEpisode(date)); !actual.Equal(date) {
+		t.Errorf("expected date %s, got %s", date, actual)
+	}
+}
+
+func TestWorker_findEpisode_SeasonsByYear(t *testing.T) {
+
+	//daily shows are often numbered by the year they aired
+	show := testShow("The Daily Show", 1996, map[int][]*types.Episode{
+		0:    {aired("Special", "2019-12-31")},
+		2019: {aired("A", "2019-12-30")},
+		2020: {aired("B", "2020-01-06"), aired("C", "2020-01-07")},
+	})
+	show.ID = 1
+	show.SeriesNumbers = []int{2019, 2020}
+
+	w := &Worker{database: &testDatabase{shows: []*types.TV{show}}}
+
+	var tests = []struct {
+		name     string
+		date     string
+		expected *testEpisode
+	}{
+		{
+			name:     "Episode",
+			date:     "2020-01-07",
+			expected: &testEpisode{Season: 2020, Episode: 2},
+		},
+		{
+			name:     "Special",
+			date:     "2019-12-31",
+			expected: &testEpisode{Season: 0, Episode: 1},
+		},
+	}
+
+	for _, test := range tests {
+		date, _ := time.Parse(airDateFormat, test.date)
+		info := &ptn.TorrentInfo{Title: show.Title, Season: datedSeason, Episode: dateEpisode(date)}
+
+		fetched := w.getTV(show.ID, info)
+		if fetched == nil {
+			t.Fatalf("%s expected show to be fetched", test.name)
+		}
+
+		if diff := pretty.Compare(test.expected, found(w.findEpisode(&filing.File{}, fetched, info))); diff != "" {
+			t.Errorf("%s unexpected diff (-want +got):\n%s", test.name, diff)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"strings"
+
+	ptn "github.com/middelink/go-parse-torrent-name"
+	"github.com/rustedturnip/media-mapper/filing"
+	"github.com/rustedturnip/media-mapper/naming"
+	"github.com/rustedturnip/media-mapper/types"
+)
+
+//parses the file's name, recognising episodes named by their air date, e.g.
+//"The.Daily.Show.2020.10.23", which are given the datedSeason, unnumbered
+//specials, e.g. "Doctor.Who.Christmas.Special", which are given season 0 and
+//the unnumberedEpisode, and episodes numbered absolutely, e.g.
+//"[Group] Show - 137 [1080p]", which are given the absoluteSeason
+func parseFile(file *filing.File) (*ptn.TorrentInfo, error) {
+
+	info, err := ptn.Parse(file.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if title, date := naming.ParseDate(file.Name); !date.IsZero() {
+		info.Title = title
+		info.Year = 0 //the year the episode aired, not the show
+		info.Season = datedSeason
+		info.Episode = dateEpisode(date)
+	} else if title, year := naming.ParseSpecial(file.Name); title != "" {
+		info.Title = title
+		info.Year = year
+		info.Season = 0
+		info.Episode = unnumberedEpisode
+	} else if title, number := naming.ParseAbsolute(file.Name); number != 0 {
+		info.Title = title
+		info.Season = absoluteSeason
+		info.Episode = number
+	}
+
+	return info, nil
+}
+
+//fetches the show with the ID, populated with the series of the file
+//described by info, or every series if its episode is numbered absolutely
+//or named by its air date (along with the specials, which may have aired on
+//the date). The series are numbered in the show's order, as listed by the
+//provider.
+func (w *Worker) getTV(id int, info *ptn.TorrentInfo) *types.TV {
+
+	order := w.episodeOrder(id)
+
+	if info.Season != absoluteSeason && info.Season != datedSeason {
+		return w.database.GetTV(id, order, info.Season)
+	}
+
+	show := w.database.GetTV(id, order)
+	if show == nil {
+		return show
+	}
+
+	//series may be numbered by year or have gaps, so are only counted from 1
+	//if the provider doesn't list them
+	series := append([]int(nil), show.SeriesNumbers...)
+	if len(series) == 0 {
+		for number := 1; number <= show.SeriesCount; number++ {
+			series = append(series, number)
+		}
+	}
+	if len(series) == 0 {
+		return show
+	}
+
+	if info.Season == datedSeason {
+		series = append(series, 0)
+	}
+
+	return w.database.GetTV(id, order, series...)
+}
+
+//returns the series and episode of show of the file described by info,
+//mapping episodes numbered absolutely or named by their air date to their
+//season, and unnumbered specials to their number. Both are nil if the show
+//doesn't have the episode.
+func (w *Worker) findEpisode(file *filing.File, show *types.TV, info *ptn.TorrentInfo) (*types.Series, *types.Episode) {
+
+	switch {
+	case info.Season == absoluteSeason:
+		return absoluteEpisode(show, info.Episode)
+	case info.Season == datedSeason:
+		return datedEpisode(show, episodeDate(info.Episode))
+	case info.Episode == unnumberedEpisode:
+		return specialEpisode(file, show)
+	}
+
+	series, ok := show.Series[info.Season]
+	if !ok {
+		return nil, nil
+	}
+
+	episode, ok := series.Episodes[info.Episode]
+	if !ok {
+		return nil, nil
+	}
+
+	return series, episode
+}
+
+//searches for the show of the file described by info. The titles of
+//unnumbered specials may run on into the special's title, e.g. "Sherlock The
+//Abominable Bride Special", so words are dropped from the end of their title
+//until shows are found, and info's title is set to the title found.
+func (w *Worker) searchTV(info *ptn.TorrentInfo) []*types.TV {
+
+	shows := w.database.SearchTV(info.Title, info.Year)
+	if info.Episode != unnumberedEpisode {
+		return shows
+	}
+
+	for words := strings.Fields(info.Title); len(shows) == 0 && len(words) > 1; {
+		words = words[:len(words)-1]
+		info.Title = strings.Join(words, " ")
+		shows = w.database.SearchTV(info.Title, info.Year)
+	}
+
+	return shows
+}
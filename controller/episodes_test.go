@@ -0,0 +1,146 @@
+package controller
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	ptn "github.com/middelink/go-parse-torrent-name"
+	"github.com/rustedturnip/media-mapper/dbs"
+	"github.com/rustedturnip/media-mapper/types"
+)
+
+//database of movies and shows held in memory, recording the series of each
+//show requested
+type testDatabase struct {
+	movies    []*types.Movie
+	shows     []*types.TV
+	requested map[int][]int //key: show ID, value: series requested
+}
+
+func (db *testDatabase) SearchMovies(title string, year int) []*types.Movie {
+
+	var movies []*types.Movie
+	for _, movie := range db.movies {
+		if strings.EqualFold(movie.Title, title) {
+			movies = append(movies, movie)
+		}
+	}
+
+	return movies
+}
+
+func (db *testDatabase) GetMovie(id int) *types.Movie {
+
+	for _, movie := range db.movies {
+		if movie.ID == id {
+			return movie
+		}
+	}
+
+	return nil
+}
+
+func (db *testDatabase) FindMovies(ids types.ExternalIDs) []*types.Movie {
+	return nil
+}
+
+//shows are found by any part of their title and without their series, as
+//when searching a provider
+func (db *testDatabase) SearchTV(title string, year int) []*types.TV {
+
+	var shows []*types.TV
+	for _, show := range db.shows {
+		if strings.Contains(strings.ToLower(show.Title), strings.ToLower(title)) {
+			found := *show
+			found.Series = make(map[int]*types.Series)
+			shows = append(shows, &found)
+		}
+	}
+
+	return shows
+}
+
+func (db *testDatabase) GetTV(id int, order dbs.Order, series ...int) *types.TV {
+
+	if db.requested == nil {
+		db.requested = make(map[int][]int)
+	}
+	db.requested[id] = series
+
+	for _, show := range db.shows {
+		if show.ID != id {
+			continue
+		}
+
+		fetched := *show
+		fetched.Series = make(map[int]*types.Series)
+		for _, number := range series {
+			if s, ok := show.Series[number]; ok {
+				fetched.Series[number] = s
+			}
+		}
+
+		return &fetched
+	}
+
+	return nil
+}
+
+func (db *testDatabase) FindTV(ids types.ExternalIDs) []*types.TV {
+	return nil
+}
+
+func TestWorker_getTV(t *testing.T) {
+
+	var tests = []struct {
+		name     string
+		numbers  []int //series numbers listed by the provider
+		count    int
+		info     *ptn.TorrentInfo
+		expected []int //series requested
+	}{
+		{
+			name:     "Season",
+			numbers:  []int{1, 2, 3},
+			count:    3,
+			info:     &ptn.TorrentInfo{Season: 3, Episode: 1},
+			expected: []int{3},
+		},
+		{
+			name:     "Absolute, Gap in Seasons",
+			numbers:  []int{1, 3, 4},
+			count:    3,
+			info:     &ptn.TorrentInfo{Season: absoluteSeason, Episode: 10},
+			expected: []int{1, 3, 4},
+		},
+		{
+			name:     "Dated, Seasons Numbered by Year",
+			numbers:  []int{2019, 2020},
+			count:    2,
+			info:     &ptn.TorrentInfo{Season: datedSeason, Episode: 20200106},
+			expected: []int{2019, 2020, 0},
+		},
+		{
+			name:     "Absolute, Seasons Not Listed",
+			count:    2,
+			info:     &ptn.TorrentInfo{Season: absoluteSeason, Episode: 10},
+			expected: []int{1, 2},
+		},
+	}
+
+	for _, test := range tests {
+		show := testShow("Show", 0, nil)
+		show.ID = 1
+		show.SeriesNumbers = test.numbers
+		show.SeriesCount = test.count
+
+		db := &testDatabase{shows: []*types.TV{show}}
+		w := &Worker{database: db}
+		w.getTV(show.ID, test.info)
+
+		if diff := pretty.Compare(test.expected, db.requested[show.ID]); diff != "" {
+			t.Errorf("%s unexpected diff (-want +got):\n%s", test.name, diff)
+		}
+	}
+}
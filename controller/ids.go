@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"path/filepath"
+
+	ptn "github.com/middelink/go-parse-torrent-name"
+	"github.com/rustedturnip/media-mapper/dbs"
+	"github.com/rustedturnip/media-mapper/filing"
+	"github.com/rustedturnip/media-mapper/naming"
+	"github.com/rustedturnip/media-mapper/types"
+)
+
+//finds the database entry for the media described by info by the IDs tagged
+//in its name or directories, e.g. {tmdb-603}, fetching it directly rather
+//than searching. Matches by ID have full confidence. Both are nil if there
+//are no IDs or no entry is found by them.
+func (w *Worker) matchID(dir string, file *filing.File, info *ptn.TorrentInfo) (*naming.Fields, *result) {
+
+	ids := w.taggedIDs(dir, file)
+	if ids == (types.ExternalIDs{}) {
+		return nil, nil
+	}
+
+	id := w.providerID(ids)
+
+	switch info.Episode {
+	case 0: //Movie
+		var movie *types.Movie
+		if id != 0 {
+			movie = w.database.GetMovie(id)
+		}
+		if movie == nil {
+			if movies := w.database.FindMovies(ids); len(movies) > 0 {
+				movie = w.movieDetails(movies[0])
+			}
+		}
+		if movie == nil {
+			return nil, nil
+		}
+
+		return w.movieMatch(info, movie, 1)
+
+	default: //Episode of TV Series
+		if id == 0 {
+			if shows := w.database.FindTV(ids); len(shows) > 0 {
+				id = shows[0].ID
+			}
+		}
+		if id == 0 {
+			return nil, nil
+		}
+
+		show := w.getTV(id, info)
+		if show == nil {
+			return nil, nil
+		}
+
+		return w.episodeMatch(file, info, show, 1)
+	}
+}
+
+//returns the IDs tagged in the file's name or, failing that, in the names of
+//the directories it's in up to the root, nearest first
+func (w *Worker) taggedIDs(dir string, file *filing.File) types.ExternalIDs {
+
+	ids := naming.ParseIDs(file.Name)
+	root := filepath.Clean(w.filer.GetRoot())
+
+	for dir = filepath.Clean(dir); ids == (types.ExternalIDs{}); dir = filepath.Dir(dir) {
+		ids = naming.ParseIDs(filepath.Base(dir))
+
+		if dir == root || dir == filepath.Dir(dir) {
+			break
+		}
+	}
+
+	return ids
+}
+
+//sets the IDs of the movie or show in each database, its ID in the
+//provider's database along with its external IDs
+func (w *Worker) setIDs(fields *naming.Fields, id int, external types.ExternalIDs) {
+
+	fields.TMDB = external.TMDB
+	fields.TVDB = external.TVDB
+	fields.IMDB = external.IMDB
+
+	switch w.provider {
+	case dbs.API_name[int(dbs.TMDB)]:
+		fields.TMDB = id
+	case dbs.API_name[int(dbs.TVDB)]:
+		fields.TVDB = id
+	}
+}
+
+//returns the ID in the provider's database from ids, 0 if it isn't tagged
+func (w *Worker) providerID(ids types.ExternalIDs) int {
+
+	switch w.provider {
+	case dbs.API_name[int(dbs.TMDB)]:
+		return ids.TMDB
+	case dbs.API_name[int(dbs.TVDB)]:
+		return ids.TVDB
+	}
+
+	return 0
+}
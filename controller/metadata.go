@@ -0,0 +1,150 @@
+package controller
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/rustedturnip/media-mapper/artwork"
+	"github.com/rustedturnip/media-mapper/filing"
+	"github.com/rustedturnip/media-mapper/nfo"
+)
+
+//renamedFile is a file renamed in a run (or already named) along with the
+//database entry it was matched with
+type renamedFile struct {
+	path   string //new path
+	name   string //new name, without extension
+	result *result
+}
+
+//returns the files renamed in run (or already named) that were matched with
+//the database
+func (w *Worker) renamed(run *filing.Run) []*renamedFile {
+
+	renamed := make(map[string]bool)
+	for _, r := range run.Renamed {
+		renamed[r.New] = true
+	}
+
+	var files []*renamedFile
+	all := w.filer.GetFiles()
+
+	for _, dir := range w.filer.GetDirs() {
+		for _, file := range all[dir] {
+			result, ok := w.results[file]
+			if !ok || file.NewName == "" {
+				continue
+			}
+
+			newPath := file.GetNewPath(dir)
+			if !renamed[filing.Absolute(newPath)] && filepath.Clean(newPath) != filepath.Clean(filepath.Join(dir, file.GetName())) {
+				continue //failed to rename
+			}
+
+			files = append(files, &renamedFile{
+				path:   newPath,
+				name:   file.NewName,
+				result: result,
+			})
+		}
+	}
+
+	return files
+}
+
+//writes the nfo of each renamed file beside it, along with the tvshow.nfo of
+//each show. The files written are recorded in run so undoing it removes
+//them. Existing nfo files are left alone.
+func (w *Worker) writeNFOs(run *filing.Run) {
+
+	shows := make(map[string]bool) //show directories written
+
+	for _, file := range w.renamed(run) {
+		result := file.result
+		path := filepath.Join(filepath.Dir(file.path), file.name+nfo.Ext)
+
+		switch {
+		case result.movie != nil:
+			w.write(run, path, func(path string) error {
+				return nfo.Write(path, nfo.NewMovie(w.provider, result.movie))
+			})
+
+		case len(result.episodes) > 0:
+			w.write(run, path, func(path string) error {
+				return nfo.Write(path, nfo.NewEpisodes(w.provider, result.show, result.series, result.episodes))
+			})
+
+			showDir := nfo.ShowDir(filepath.Dir(file.path))
+			if !shows[showDir] {
+				shows[showDir] = true
+				w.write(run, filepath.Join(showDir, nfo.TVShowFile), func(path string) error {
+					return nfo.Write(path, nfo.NewTVShow(w.provider, result.show))
+				})
+			}
+		}
+	}
+}
+
+//downloads the poster and fanart of each renamed movie, named after it
+//unless organised into its own directory, and of each show into its
+//directory along with its season posters. The files downloaded are
+//recorded in run so undoing it removes them. Existing files are left alone.
+func (w *Worker) downloadArtwork(run *filing.Run) {
+
+	done := make(map[string]bool) //artwork downloaded, by destination
+
+	download := func(artworkPath, dir, prefix, kind string) {
+		if artworkPath == "" {
+			return
+		}
+
+		dest := filepath.Join(dir, artwork.Name(prefix, kind, artworkPath))
+		if done[dest] {
+			return
+		}
+		done[dest] = true
+
+		w.write(run, dest, func(path string) error {
+			return w.artwork.Download(artworkPath, path)
+		})
+	}
+
+	for _, file := range w.renamed(run) {
+		result := file.result
+		dir := filepath.Dir(file.path)
+
+		switch {
+		case result.movie != nil:
+			prefix := file.name + "-"
+			if w.organise {
+				prefix = "" //in its own directory
+			}
+
+			download(result.movie.Poster, dir, prefix, artwork.Poster)
+			download(result.movie.Fanart, dir, prefix, artwork.Fanart)
+
+		case len(result.episodes) > 0:
+			showDir := nfo.ShowDir(dir)
+
+			download(result.show.Poster, showDir, "", artwork.Poster)
+			download(result.show.Fanart, showDir, "", artwork.Fanart)
+			download(result.series.Poster, showDir, artwork.SeasonPrefix(result.series.Number), artwork.Poster)
+		}
+	}
+}
+
+//writes a file to path with write, recording it in run. Existing files are
+//left alone.
+func (w *Worker) write(run *filing.Run, path string, write func(path string) error) {
+
+	if err := write(path); err != nil {
+		if !os.IsExist(err) {
+			log.Println(fmt.Sprintf("Failed to write %s with error: %s", path, err.Error()))
+		}
+		return
+	}
+
+	run.Written = append(run.Written, filing.Absolute(path))
+}
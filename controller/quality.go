@@ -0,0 +1,54 @@
+package controller
+
+import (
+	"path/filepath"
+	"strings"
+
+	ptn "github.com/middelink/go-parse-torrent-name"
+)
+
+var (
+	//scores of the resolutions and sources (ptn's quality) of releases,
+	//resolution outweighs source
+	resolutionScores = map[string]int{
+		"2160p": 400,
+		"1080p": 300,
+		"720p":  200,
+		"576p":  100,
+		"480p":  100,
+	}
+
+	sourceScores = map[string]int{
+		"bluray": 50,
+		"bdrip":  40,
+		"brrip":  40,
+		"web-dl": 40,
+		"webdl":  40,
+		"webrip": 30,
+		"hdrip":  30,
+		"hdtv":   20,
+		"pdtv":   20,
+		"dvdrip": 10,
+	}
+)
+
+//quality scores the quality of the media file at path using the tags in
+//its name, so that the best of several conflicting files can be kept
+func quality(path string) int {
+
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+
+	info, err := ptn.Parse(name)
+	if err != nil {
+		return 0
+	}
+
+	score := resolutionScores[strings.ToLower(info.Resolution)] + sourceScores[strings.ToLower(info.Quality)]
+
+	//fixed releases replace the original
+	if info.Proper || info.Repack {
+		score++
+	}
+
+	return score
+}
@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/rustedturnip/media-mapper/filing"
+)
+
+func TestQuality(t *testing.T) {
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected int
+	}{
+		{
+			name:     "Resolution and Source",
+			input:    "Show.S01E01.480p.HDTV.x264.mkv",
+			expected: 120,
+		},
+		{
+			name:     "Repack",
+			input:    "Show.S01E01.1080p.BluRay.REPACK.mkv",
+			expected: 351,
+		},
+		{
+			name:     "Already Named",
+			input:    "Show - 1x1 - Pilot.mkv",
+			expected: 0,
+		},
+	}
+
+	for _, test := range tests {
+		if actual := quality(filepath.Join("/media", test.input)); actual != test.expected {
+			t.Errorf("%s expected %d, got %d", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestResolveConflicts_Quality(t *testing.T) {
+
+	var tests = []struct {
+		name     string
+		existing map[string]int64  //name: size of files already named
+		renames  map[string]string //old name: new name
+		expected map[string]string //old name: new name
+	}{
+		{
+			name:     "Existing Larger",
+			existing: map[string]int64{"Show - 1x1 - Pilot.mkv": 100},
+			renames: map[string]string{
+				"Show.S01E01.480p.HDTV.mkv": "Show - 1x1 - Pilot",
+			},
+			expected: map[string]string{
+				"Show.S01E01.480p.HDTV.mkv": "",
+			},
+		},
+		{
+			name: "Highest Quality Release",
+			renames: map[string]string{
+				"Show.S01E01.480p.HDTV.mkv":        "Show - 1x1 - Pilot",
+				"Show.S01E01.1080p.WEB-DL.mkv":     "Show - 1x1 - Pilot",
+				"Show.S01E01.720p.BluRay.mkv":      "Show - 1x1 - Pilot",
+				"Show.S01E02.480p.HDTV.mkv":        "Show - 1x2 - Second",
+				"Show.S01E02.480p.HDTV.PROPER.mkv": "Show - 1x2 - Second",
+			},
+			expected: map[string]string{
+				"Show.S01E01.480p.HDTV.mkv":        "",
+				"Show.S01E01.1080p.WEB-DL.mkv":     "Show - 1x1 - Pilot",
+				"Show.S01E01.720p.BluRay.mkv":      "",
+				"Show.S01E02.480p.HDTV.mkv":        "",
+				"Show.S01E02.480p.HDTV.PROPER.mkv": "Show - 1x2 - Second",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		dir, err := ioutil.TempDir("", "media-mapper-controller")
+		if err != nil {
+			t.Fatalf("unable to create dir: %s", err.Error())
+		}
+		defer os.RemoveAll(dir)
+
+		for name, size := range test.existing {
+			if err := ioutil.WriteFile(filepath.Join(dir, name), make([]byte, size), 0644); err != nil {
+				t.Fatalf("unable to create file: %s", err.Error())
+			}
+		}
+		for name := range test.renames {
+			if err := ioutil.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+				t.Fatalf("unable to create file: %s", err.Error())
+			}
+		}
+
+		filer, err := filing.New(dir)
+		if err != nil {
+			t.Fatalf("unable to find files: %s", err.Error())
+		}
+
+		for _, file := range filer.GetFiles()[dir] {
+			file.NewName = test.renames[file.GetName()]
+		}
+
+		filer.ResolveConflicts(filer.FindConflicts(), filing.StrategyQuality, quality)
+
+		actual := make(map[string]string)
+		for _, file := range filer.GetFiles()[dir] {
+			if _, ok := test.renames[file.GetName()]; ok {
+				actual[file.GetName()] = file.NewName
+			}
+		}
+
+		if diff := pretty.Compare(test.expected, actual); diff != "" {
+			t.Errorf("%s unexpected diff (-want +got):\n%s", test.name, diff)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	colour "github.com/fatih/color"
+	"github.com/rustedturnip/media-mapper/filing"
+)
+
+//review walks each proposed rename in turn, letting the user accept, skip,
+//edit or re-search it. Skipped files are left out of the batch rename.
+func (w *Worker) review() {
+
+	files := w.filer.GetFiles()
+
+	for _, dir := range w.filer.GetDirs() {
+		for _, file := range files[dir] {
+			if file.NewName == "" {
+				continue
+			}
+
+			w.reviewFile(dir, file)
+		}
+	}
+}
+
+func (w *Worker) reviewFile(dir string, file *filing.File) {
+
+	for {
+		fmt.Printf("\n%s:\n", filepath.Join(dir, file.GetName()))
+		colour.Red("- %s", file.GetName())
+		colour.Green("+ %s", w.filer.DisplayNewName(dir, file))
+
+		switch strings.ToLower(w.prompt("[a]ccept, [s]kip, [e]dit or [r]e-search (default a): ")) {
+		case "", "a":
+			return
+
+		case "s":
+			file.NewName = ""
+			file.NewDir = ""
+			return
+
+		case "e":
+			name := w.prompt("New name (without extension): ")
+			if name = w.sanitiser.Name(strings.TrimSuffix(name, file.Ext), file.Ext); name != "" {
+				file.NewName = name
+			}
+
+		case "r":
+			if w.database == nil {
+				colour.Yellow("! can't re-search when applying a plan")
+				continue
+			}
+
+			query := w.prompt("Search for: ")
+			if query == "" {
+				continue
+			}
+
+			info, err := parseFile(file)
+			if err != nil {
+				colour.Yellow("! %s", fmt.Errorf(parseErr, file.GetName(), err))
+				continue
+			}
+			info.Title = query
+
+			//the user is judging the result so the confidence threshold doesn't apply
+			fields, result := w.match(dir, file, info)
+			if fields == nil {
+				colour.Yellow("! no match found for %q", query)
+				continue
+			}
+
+			if name, newDir, err := w.newName(file, info, fields); err != nil {
+				colour.Yellow("! %s", fmt.Sprintf(namingErr, file.GetName(), err))
+			} else {
+				file.NewName = name
+				file.NewDir = newDir
+				w.results[file] = result
+			}
+		}
+	}
+}
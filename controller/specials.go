@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/rustedturnip/media-mapper/filing"
+	"github.com/rustedturnip/media-mapper/naming"
+	"github.com/rustedturnip/media-mapper/types"
+)
+
+const (
+	//episode of files which are specials (season 0) or extras without a
+	//number, e.g. "Show.Christmas.Special", which are matched by their title
+	unnumberedEpisode = -1
+
+	specialTitleWeight = 2 //score of each word a special's title shares with the file's name
+	specialYearWeight  = 1 //score of a special which aired in a year given in the file's name
+)
+
+//returns the special of show the file is, that whose title shares the most
+//words with the file's name, favouring specials which aired in a year given
+//in the name. Names with only a year match the special which aired that
+//year, if only one did. Both are nil if no special matches.
+func specialEpisode(file *filing.File, show *types.TV) (*types.Series, *types.Episode) {
+
+	series, ok := show.Series[0]
+	if !ok {
+		return nil, nil
+	}
+
+	//the show's title and year are in the name, but not in the special's title
+	ignored := make(map[string]bool)
+	for _, word := range naming.SpecialWords(show.Title) {
+		ignored[word] = true
+	}
+	if !show.ReleaseDate.IsZero() {
+		ignored[strconv.Itoa(show.ReleaseDate.Year())] = true
+	}
+
+	var words []string
+	years := make(map[int]bool)
+	for _, word := range naming.SpecialWords(file.Name) {
+		if ignored[word] {
+			continue
+		}
+
+		if year, err := strconv.Atoi(word); err == nil && year >= 1900 && year < 2100 {
+			years[year] = true
+			continue
+		}
+		words = append(words, word)
+	}
+
+	var numbers []int
+	for number := range series.Episodes {
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+
+	var best *types.Episode
+	var aired []*types.Episode //specials which aired in a year given in the name
+	bestScore := 0
+
+	for _, number := range numbers {
+		episode := series.Episodes[number]
+
+		airedInYear := !episode.AirDate.IsZero() && years[episode.AirDate.Year()]
+		if airedInYear {
+			aired = append(aired, episode)
+		}
+
+		title := make(map[string]bool)
+		for _, word := range naming.SpecialWords(episode.Title) {
+			title[word] = true
+		}
+
+		score := 0
+		for _, word := range words {
+			if title[word] {
+				score += specialTitleWeight
+			}
+		}
+		if score == 0 {
+			continue //must share a word of its title
+		}
+
+		if airedInYear {
+			score += specialYearWeight
+		}
+
+		if score > bestScore {
+			best, bestScore = episode, score
+		}
+	}
+
+	if best == nil && len(aired) == 1 {
+		best = aired[0]
+	}
+
+	if best == nil {
+		return nil, nil
+	}
+
+	return series, best
+}
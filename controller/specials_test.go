@@ -0,0 +1,156 @@
+package controller
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/rustedturnip/media-mapper/filing"
+	"github.com/rustedturnip/media-mapper/types"
+)
+
+func TestSpecialEpisode(t *testing.T) {
+
+	specials := func() map[int][]*types.Episode {
+		return map[int][]*types.Episode{
+			0: {
+				aired("The Christmas Invasion", "2005-12-25"),
+				aired("The Runaway Bride", "2006-12-25"),
+				aired("Voyage of the Damned", "2007-12-25"),
+				aired("Doctor Who at the Proms", "2008-07-27"),
+				aired("The Next Doctor", "2008-12-25"),
+				aired("A Christmas Carol", "2010-12-25"),
+			},
+			1: {aired("Rose", "2005-03-26")},
+		}
+	}
+
+	var tests = []struct {
+		name     string
+		input    string
+		series   map[int][]*types.Episode
+		expected *testEpisode
+	}{
+		{
+			name:     "Title",
+			input:    "Doctor.Who.2005.The.Runaway.Bride.Special",
+			series:   specials(),
+			expected: &testEpisode{Season: 0, Episode: 2},
+		},
+		{
+			name:     "Year Favoured",
+			input:    "Doctor.Who.2005.Christmas.Special.2010.720p.HDTV",
+			series:   specials(),
+			expected: &testEpisode{Season: 0, Episode: 6},
+		},
+		{
+			name:     "Year Only",
+			input:    "Doctor.Who.Special.2007",
+			series:   specials(),
+			expected: &testEpisode{Season: 0, Episode: 3},
+		},
+		{
+			name:   "Year Only, Several Aired",
+			input:  "Doctor.Who.Special.2008",
+			series: specials(),
+		},
+		{
+			name:   "Show Year Ignored",
+			input:  "Doctor.Who.2005.Special",
+			series: specials(),
+		},
+		{
+			name:   "Show Title Ignored",
+			input:  "Doctor.Who.Special",
+			series: specials(),
+		},
+		{
+			name:   "No Shared Words",
+			input:  "Doctor.Who.Extras.Behind.the.Scenes",
+			series: specials(),
+		},
+		{
+			name:   "No Specials",
+			input:  "Doctor.Who.2005.The.Runaway.Bride.Special",
+			series: map[int][]*types.Episode{1: {aired("Rose", "2005-03-26")}},
+		},
+	}
+
+	for _, test := range tests {
+		show := testShow("Doctor Who", 2005, test.series)
+		file := &filing.File{Name: test.input}
+
+		if diff := pretty.Compare(test.expected, found(specialEpisode(file, show))); diff != "" {
+			t.Errorf("%s unexpected diff (-want +got):\n%s", test.name, diff)
+		}
+	}
+}
+
+func TestWorker_lookup_Specials(t *testing.T) {
+
+	//shows with specials which share words with names of movies
+	the := testShow("The Middle", 2009, map[int][]*types.Episode{
+		0: {aired("The Extra Mile", "2010-12-08")},
+	})
+	the.ID = 1
+
+	ocean := testShow("Ocean Girl", 1994, map[int][]*types.Episode{
+		0: {aired("Forces of Nature", "2019-06-01")},
+	})
+	ocean.ID = 2
+
+	db := &testDatabase{
+		shows: []*types.TV{the, ocean},
+		movies: []*types.Movie{
+			{ID: 10, Title: "The Extra Man", ReleaseDate: time.Date(2010, 7, 30, 0, 0, 0, 0, time.UTC)},
+			{ID: 11, Title: "Ocean Special Forces", ReleaseDate: time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Movie With Special Marker",
+			input:    "The.Extra.Man.2010.mkv",
+			expected: "The Extra Man (2010)",
+		},
+		{
+			name:     "Movie Better Than Special",
+			input:    "Ocean.Special.Forces.2019.mkv",
+			expected: "Ocean Special Forces (2019)",
+		},
+	}
+
+	for _, test := range tests {
+		dir, err := ioutil.TempDir("", "media-mapper-controller")
+		if err != nil {
+			t.Fatalf("unable to create dir: %s", err.Error())
+		}
+		defer os.RemoveAll(dir)
+
+		if err := ioutil.WriteFile(filepath.Join(dir, test.input), nil, 0644); err != nil {
+			t.Fatalf("unable to create file: %s", err.Error())
+		}
+
+		filer, err := filing.New(dir)
+		if err != nil {
+			t.Fatalf("unable to find files: %s", err.Error())
+		}
+
+		w := New(db, filer, Options{Streamline: true, MinConfidence: 0.6})
+		file := filer.GetFiles()[dir][0]
+		if _, err := w.lookup(dir, file); err != nil {
+			t.Errorf("%s unexpected error: %s", test.name, err.Error())
+		}
+
+		if file.NewName != test.expected {
+			t.Errorf("%s expected %q, got %q", test.name, test.expected, file.NewName)
+		}
+	}
+}
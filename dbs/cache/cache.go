@@ -0,0 +1,352 @@
+package cache
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rustedturnip/media-mapper/dbs"
+	"github.com/rustedturnip/media-mapper/types"
+)
+
+const (
+	//kinds of cached lookup, each with their own TTL
+	KindSearch = "search"
+	KindShow   = "show" //shows and movies
+
+	dirName = "media-mapper"
+	fileExt = ".json"
+)
+
+//TTL is how long each kind of lookup is cached for
+type TTL struct {
+	Search time.Duration
+	Show   time.Duration
+}
+
+//Entry is a single cached lookup
+type Entry struct {
+	Provider string          `json:"provider"`
+	Kind     string          `json:"kind"`
+	Key      string          `json:"key"`
+	Stored   time.Time       `json:"stored"`
+	Value    json.RawMessage `json:"value"`
+
+	path string
+}
+
+//Expired reports whether the entry is older than its kind's TTL
+func (e *Entry) Expired(ttl TTL, now time.Time) bool {
+
+	switch e.Kind {
+	case KindSearch:
+		return now.Sub(e.Stored) > ttl.Search
+	case KindShow:
+		return now.Sub(e.Stored) > ttl.Show
+	default:
+		return true
+	}
+}
+
+//Cache wraps a dbs.Database, storing the results of its lookups on disk so
+//that they can be reused by later runs. Entries are stored per provider and
+//keyed by the lookup and its query.
+//
+//When offline, lookups are answered purely from the cache, regardless of
+//whether entries have expired, and db may be nil.
+type Cache struct {
+	db       dbs.Database
+	provider string
+	dir      string
+	ttl      TTL
+	offline  bool
+}
+
+func New(db dbs.Database, provider, dir string, ttl TTL, offline bool) dbs.Database {
+	return &Cache{
+		db:       db,
+		provider: provider,
+		dir:      dir,
+		ttl:      ttl,
+		offline:  offline,
+	}
+}
+
+//DefaultDir returns the cache directory under the user's cache dir
+func DefaultDir() (string, error) {
+
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, dirName), nil
+}
+
+func (c *Cache) SearchMovies(title string, year int) []*types.Movie {
+
+	key := fmt.Sprintf("movies:%q:%d", title, year)
+
+	var movies []*types.Movie
+	if c.get(KindSearch, key, &movies) || c.offline {
+		return movies
+	}
+
+	movies = c.db.SearchMovies(title, year)
+	if len(movies) > 0 {
+		c.put(KindSearch, key, movies)
+	}
+
+	return movies
+}
+
+func (c *Cache) GetMovie(id int) *types.Movie {
+
+	key := fmt.Sprintf("movie:%d", id)
+
+	var movie *types.Movie
+	if c.get(KindShow, key, &movie) || c.offline {
+		return movie
+	}
+
+	movie = c.db.GetMovie(id)
+	if movie != nil {
+		c.put(KindShow, key, movie)
+	}
+
+	return movie
+}
+
+func (c *Cache) FindMovies(ids types.ExternalIDs) []*types.Movie {
+
+	key := fmt.Sprintf("find-movies:%q:%d:%d", ids.IMDB, ids.TMDB, ids.TVDB)
+
+	var movies []*types.Movie
+	if c.get(KindSearch, key, &movies) || c.offline {
+		return movies
+	}
+
+	movies = c.db.FindMovies(ids)
+	if len(movies) > 0 {
+		c.put(KindSearch, key, movies)
+	}
+
+	return movies
+}
+
+func (c *Cache) SearchTV(title string, year int) []*types.TV {
+
+	key := fmt.Sprintf("tv:%q:%d", title, year)
+
+	var shows []*types.TV
+	if c.get(KindSearch, key, &shows) || c.offline {
+		return shows
+	}
+
+	shows = c.db.SearchTV(title, year)
+	if len(shows) > 0 {
+		c.put(KindSearch, key, shows)
+	}
+
+	return shows
+}
+
+func (c *Cache) GetTV(id int, order dbs.Order, series ...int) *types.TV {
+
+	key := fmt.Sprintf("tv:%d:%s:%v", id, order, series)
+
+	var show *types.TV
+	if c.get(KindShow, key, &show) || c.offline {
+		return show
+	}
+
+	show = c.db.GetTV(id, order, series...)
+	if show != nil {
+		c.put(KindShow, key, show)
+	}
+
+	return show
+}
+
+func (c *Cache) FindTV(ids types.ExternalIDs) []*types.TV {
+
+	key := fmt.Sprintf("find-tv:%q:%d:%d", ids.IMDB, ids.TMDB, ids.TVDB)
+
+	var shows []*types.TV
+	if c.get(KindSearch, key, &shows) || c.offline {
+		return shows
+	}
+
+	shows = c.db.FindTV(ids)
+	if len(shows) > 0 {
+		c.put(KindSearch, key, shows)
+	}
+
+	return shows
+}
+
+//reads the entry for key into value, returning false if there isn't an
+//entry or it has expired
+func (c *Cache) get(kind, key string, value interface{}) bool {
+
+	entry, err := readEntry(c.path(key))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Println(fmt.Sprintf("Failed reading cache entry %s with error: %s", key, err.Error()))
+		}
+		return false
+	}
+
+	if entry.Kind != kind || (!c.offline && entry.Expired(c.ttl, time.Now())) {
+		return false
+	}
+
+	if err := json.Unmarshal(entry.Value, value); err != nil {
+		log.Println(fmt.Sprintf("Failed reading cache entry %s with error: %s", key, err.Error()))
+		return false
+	}
+
+	return true
+}
+
+//stores value as the entry for key, failures are logged as the lookup
+//itself has still succeeded
+func (c *Cache) put(kind, key string, value interface{}) {
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		log.Println(fmt.Sprintf("Failed caching %s with error: %s", key, err.Error()))
+		return
+	}
+
+	entry := &Entry{
+		Provider: c.provider,
+		Kind:     kind,
+		Key:      key,
+		Stored:   time.Now(),
+		Value:    data,
+	}
+
+	if err := writeEntry(c.path(key), entry); err != nil {
+		log.Println(fmt.Sprintf("Failed caching %s with error: %s", key, err.Error()))
+	}
+}
+
+//entries are named by a hash of their key, as keys contain titles that
+//aren't safe to use in file names
+func (c *Cache) path(key string) string {
+
+	hash := sha1.Sum([]byte(key))
+
+	return filepath.Join(c.dir, c.provider, hex.EncodeToString(hash[:])+fileExt)
+}
+
+func readEntry(path string) (*Entry, error) {
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entry *Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, err
+	}
+	entry.path = path
+
+	return entry, nil
+}
+
+//writes to a temporary file first so readers never see a partial entry
+func writeEntry(path string, entry *Entry) error {
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+//List returns every entry in the cache dir, across all providers
+func List(dir string) ([]*Entry, error) {
+
+	var entries []*Entry
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil //nothing cached yet
+			}
+			return err
+		}
+
+		if info.IsDir() || !strings.HasSuffix(path, fileExt) {
+			return nil
+		}
+
+		entry, err := readEntry(path)
+		if err != nil {
+			log.Println(fmt.Sprintf("Failed reading cache entry %s with error: %s", path, err.Error()))
+			return nil
+		}
+
+		entries = append(entries, entry)
+		return nil
+	})
+
+	return entries, err
+}
+
+//Purge removes the entries in the cache dir, or only those that have
+//expired if expiredOnly is set, returning how many were removed
+func Purge(dir string, ttl TTL, expiredOnly bool) (int, error) {
+
+	entries, err := List(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	removed := 0
+	for _, entry := range entries {
+		if expiredOnly && !entry.Expired(ttl, now) {
+			continue
+		}
+
+		if err := os.Remove(entry.path); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+
+	return removed, nil
+}
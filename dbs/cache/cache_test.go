@@ -0,0 +1,243 @@
+package cache
+
+import (
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/rustedturnip/media-mapper/dbs"
+	"github.com/rustedturnip/media-mapper/types"
+)
+
+var (
+	fresh   = TTL{Search: time.Hour, Show: time.Hour}
+	expired = TTL{Search: -time.Second, Show: -time.Second} //everything is expired
+)
+
+//counts the lookups that reach the database
+type countingDatabase struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (db *countingDatabase) count(call string) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.calls[call]++
+}
+
+func (db *countingDatabase) SearchMovies(title string, year int) []*types.Movie {
+	db.count("SearchMovies " + title)
+	return []*types.Movie{{Title: title}}
+}
+
+func (db *countingDatabase) GetMovie(id int) *types.Movie {
+	db.count("GetMovie")
+	return &types.Movie{ID: id}
+}
+
+func (db *countingDatabase) FindMovies(ids types.ExternalIDs) []*types.Movie {
+	db.count("FindMovies " + ids.IMDB)
+	return []*types.Movie{{Title: ids.IMDB}}
+}
+
+func (db *countingDatabase) SearchTV(title string, year int) []*types.TV {
+	db.count("SearchTV " + title)
+	if title == "Unknown" {
+		return []*types.TV{}
+	}
+	return []*types.TV{{Title: title}}
+}
+
+func (db *countingDatabase) GetTV(id int, order dbs.Order, series ...int) *types.TV {
+	db.count("GetTV")
+	return &types.TV{ID: id}
+}
+
+func (db *countingDatabase) FindTV(ids types.ExternalIDs) []*types.TV {
+	db.count("FindTV " + ids.IMDB)
+	return []*types.TV{{Title: ids.IMDB}}
+}
+
+func TestCache(t *testing.T) {
+
+	var tests = []struct {
+		name     string
+		ttl      TTL
+		lookups  func(db dbs.Database)
+		expected map[string]int
+	}{
+		{
+			name: "Cached Between Runs",
+			ttl:  fresh,
+			lookups: func(db dbs.Database) {
+				db.SearchTV("Broadchurch", 0)
+				db.SearchTV("Broadchurch", 2013) //different query
+				db.SearchMovies("Broadchurch", 0)
+				db.GetTV(1, dbs.AiredOrder, 2)
+				db.GetTV(1, dbs.AiredOrder, 3)
+				db.GetTV(1, dbs.DVDOrder, 2) //different order
+				db.GetMovie(1)
+				db.FindTV(types.ExternalIDs{IMDB: "tt2249364"})
+			},
+			expected: map[string]int{
+				"SearchTV Broadchurch":     2,
+				"SearchMovies Broadchurch": 1,
+				"GetTV":                    3,
+				"GetMovie":                 1,
+				"FindTV tt2249364":         1,
+			},
+		},
+		{
+			name: "Expired",
+			ttl:  expired,
+			lookups: func(db dbs.Database) {
+				db.SearchTV("Broadchurch", 0)
+				db.GetTV(1, dbs.AiredOrder, 2)
+			},
+			expected: map[string]int{
+				"SearchTV Broadchurch": 2,
+				"GetTV":                2,
+			},
+		},
+		{
+			name: "Empty Results Not Cached",
+			ttl:  fresh,
+			lookups: func(db dbs.Database) {
+				db.SearchTV("Unknown", 0)
+			},
+			expected: map[string]int{
+				"SearchTV Unknown": 2,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		dir := tempDir(t)
+
+		db := &countingDatabase{
+			calls: make(map[string]int),
+		}
+
+		//each run uses a new cache, as separate runs would
+		for run := 0; run < 2; run++ {
+			test.lookups(New(db, "TMDB", dir, test.ttl, false))
+		}
+
+		if diff := pretty.Compare(test.expected, db.calls); diff != "" {
+			t.Errorf("%s unexpected diff (-want +got):\n%s", test.name, diff)
+		}
+	}
+}
+
+func TestCache_Offline(t *testing.T) {
+
+	dir := tempDir(t)
+
+	db := &countingDatabase{
+		calls: make(map[string]int),
+	}
+
+	online := New(db, "TMDB", dir, fresh, false)
+	online.SearchTV("Broadchurch", 0)
+	online.GetTV(1, dbs.AiredOrder, 2)
+
+	//offline uses expired entries and has no database to fall back on
+	offline := New(nil, "TMDB", dir, expired, true)
+
+	var tests = []struct {
+		name     string
+		actual   interface{}
+		expected interface{}
+	}{
+		{
+			name:     "Cached Search",
+			actual:   offline.SearchTV("Broadchurch", 0),
+			expected: []*types.TV{{Title: "Broadchurch"}},
+		},
+		{
+			name:     "Uncached Search",
+			actual:   offline.SearchTV("Broadchurch", 2013),
+			expected: []*types.TV(nil),
+		},
+		{
+			name:     "Cached Show",
+			actual:   offline.GetTV(1, dbs.AiredOrder, 2),
+			expected: &types.TV{ID: 1},
+		},
+		{
+			name:     "Uncached Show",
+			actual:   offline.GetTV(1, dbs.AiredOrder, 3),
+			expected: (*types.TV)(nil),
+		},
+		{
+			name:     "Uncached Order",
+			actual:   offline.GetTV(1, dbs.DVDOrder, 2),
+			expected: (*types.TV)(nil),
+		},
+		{
+			name:     "Other Provider",
+			actual:   New(nil, "TVDB", dir, fresh, true).SearchTV("Broadchurch", 0),
+			expected: []*types.TV(nil),
+		},
+	}
+
+	for _, test := range tests {
+		if diff := pretty.Compare(test.expected, test.actual); diff != "" {
+			t.Errorf("%s unexpected diff (-want +got):\n%s", test.name, diff)
+		}
+	}
+}
+
+func TestPurge(t *testing.T) {
+
+	dir := tempDir(t)
+
+	db := &countingDatabase{
+		calls: make(map[string]int),
+	}
+
+	c := New(db, "TMDB", dir, fresh, false)
+	c.SearchTV("Broadchurch", 0)
+	c.SearchMovies("Broadchurch", 0)
+	c.GetTV(1, dbs.AiredOrder, 2)
+
+	//only search results have expired
+	removed, err := Purge(dir, TTL{Search: -time.Second, Show: time.Hour}, true)
+	if err != nil {
+		t.Fatalf("unexpected error purging expired entries: %s", err.Error())
+	}
+	if removed != 2 {
+		t.Errorf("expected 2 expired entries to be purged, got %d", removed)
+	}
+
+	removed, err = Purge(dir, fresh, false)
+	if err != nil {
+		t.Fatalf("unexpected error purging entries: %s", err.Error())
+	}
+	if removed != 1 {
+		t.Errorf("expected 1 remaining entry to be purged, got %d", removed)
+	}
+
+	entries, err := List(dir)
+	if err != nil {
+		t.Fatalf("unexpected error listing entries: %s", err.Error())
+	}
+	if len(entries) != 0 {
+		t.Errorf("expected empty cache after purge, got %d entries", len(entries))
+	}
+}
+
+func tempDir(t *testing.T) string {
+
+	dir, err := ioutil.TempDir("", "media-mapper-cache")
+	if err != nil {
+		t.Fatalf("unable to create cache dir: %s", err.Error())
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	return dir
+}
@@ -7,9 +7,48 @@ import (
 	"io/ioutil"
 )
 
+//Database is implemented by each supported online media database. Searches
+//take the title and, if known (otherwise 0), the year of release which
+//implementations use to narrow results, falling back to searching without it
+//when nothing is found.
+//
+//Shows returned by SearchTV are lightweight and have no series, these are
+//fetched only when needed with GetTV which returns the show populated with
+//the requested series (by number), or nil if the show can't be fetched. The
+//series and their episodes are numbered in the given order.
+//
+//Movies returned by SearchMovies may lack details only fetched by GetMovie,
+//such as their external IDs. GetMovie returns nil if the movie can't be
+//fetched, or the database has no movies.
+//
+//FindMovies and FindTV look up media by its IDs in other databases (e.g. its
+//IMDb ID), returning results like the searches. IDs in the database's own
+//namespace are ignored, the media is fetched directly with GetMovie or GetTV.
 type Database interface {
-	SearchMovies(string) []*types.Movie
-	SearchTV(string) []*types.TV
+	SearchMovies(title string, year int) []*types.Movie
+	GetMovie(id int) *types.Movie
+	FindMovies(ids types.ExternalIDs) []*types.Movie
+	SearchTV(title string, year int) []*types.TV
+	GetTV(id int, order Order, series ...int) *types.TV
+	FindTV(ids types.ExternalIDs) []*types.TV
+}
+
+//Order is the order a show's episodes are numbered in, one of those below
+//or, for TMDB, the ID of one of the show's episode groups. TVDB numbers
+//specials (series 0) as they aired whatever the order.
+type Order string
+
+const (
+	AiredOrder    Order = "aired"    //as broadcast, the default
+	DVDOrder      Order = "dvd"      //as released on DVD and Blu-ray
+	AbsoluteOrder Order = "absolute" //a single series numbered from the first episode
+)
+
+//Orders are the orders every database supports, by name
+var Orders = map[string]Order{
+	string(AiredOrder):    AiredOrder,
+	string(DVDOrder):      DVDOrder,
+	string(AbsoluteOrder): AbsoluteOrder,
 }
 
 type API int
@@ -0,0 +1,126 @@
+package memo
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/rustedturnip/media-mapper/dbs"
+	"github.com/rustedturnip/media-mapper/types"
+)
+
+//call is a single lookup, done is closed once value has been set or the
+//lookup has panicked
+type call struct {
+	done     chan struct{}
+	value    interface{}
+	panicked bool
+}
+
+//Memo wraps a dbs.Database so that identical lookups made during a run are
+//only made once. Lookups for the same key made while the first is still in
+//flight wait for its result rather than making their own.
+//
+//Results are shared between callers so must not be modified.
+type Memo struct {
+	db    dbs.Database
+	mu    sync.Mutex
+	calls map[string]*call
+}
+
+func New(db dbs.Database) dbs.Database {
+	return &Memo{
+		db:    db,
+		calls: make(map[string]*call),
+	}
+}
+
+func (m *Memo) SearchMovies(title string, year int) []*types.Movie {
+
+	key := fmt.Sprintf("movies:%q:%d", title, year)
+
+	return m.do(key, func() interface{} {
+		return m.db.SearchMovies(title, year)
+	}).([]*types.Movie)
+}
+
+func (m *Memo) GetMovie(id int) *types.Movie {
+
+	key := fmt.Sprintf("movie:%d", id)
+
+	return m.do(key, func() interface{} {
+		return m.db.GetMovie(id)
+	}).(*types.Movie)
+}
+
+func (m *Memo) FindMovies(ids types.ExternalIDs) []*types.Movie {
+
+	key := fmt.Sprintf("find-movies:%q:%d:%d", ids.IMDB, ids.TMDB, ids.TVDB)
+
+	return m.do(key, func() interface{} {
+		return m.db.FindMovies(ids)
+	}).([]*types.Movie)
+}
+
+func (m *Memo) SearchTV(title string, year int) []*types.TV {
+
+	key := fmt.Sprintf("tv:%q:%d", title, year)
+
+	return m.do(key, func() interface{} {
+		return m.db.SearchTV(title, year)
+	}).([]*types.TV)
+}
+
+func (m *Memo) GetTV(id int, order dbs.Order, series ...int) *types.TV {
+
+	key := fmt.Sprintf("tv:%d:%s:%v", id, order, series)
+
+	return m.do(key, func() interface{} {
+		return m.db.GetTV(id, order, series...)
+	}).(*types.TV)
+}
+
+func (m *Memo) FindTV(ids types.ExternalIDs) []*types.TV {
+
+	key := fmt.Sprintf("find-tv:%q:%d:%d", ids.IMDB, ids.TMDB, ids.TVDB)
+
+	return m.do(key, func() interface{} {
+		return m.db.FindTV(ids)
+	}).([]*types.TV)
+}
+
+//returns the result of the call for key, making it with fetch if it hasn't
+//been made already. If fetch panics, callers waiting on it panic too rather
+//than blocking, and the call is forgotten so it's made again next time.
+func (m *Memo) do(key string, fetch func() interface{}) interface{} {
+
+	m.mu.Lock()
+	if c, ok := m.calls[key]; ok {
+		m.mu.Unlock()
+		<-c.done
+		if c.panicked {
+			panic(fmt.Sprintf("memo: lookup %s panicked", key))
+		}
+		return c.value
+	}
+
+	c := &call{
+		done:     make(chan struct{}),
+		panicked: true, //until fetch returns
+	}
+	m.calls[key] = c
+	m.mu.Unlock()
+
+	defer func() {
+		if c.panicked {
+			m.mu.Lock()
+			delete(m.calls, key)
+			m.mu.Unlock()
+		}
+		close(c.done)
+	}()
+
+	c.value = fetch()
+	c.panicked = false
+
+	return c.value
+}
@@ -0,0 +1,192 @@
+package memo
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/rustedturnip/media-mapper/dbs"
+	"github.com/rustedturnip/media-mapper/types"
+)
+
+//counts the lookups that reach the database
+type countingDatabase struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (db *countingDatabase) count(call string) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.calls[call]++
+}
+
+func (db *countingDatabase) SearchMovies(title string, year int) []*types.Movie {
+	db.count("SearchMovies " + title)
+	return []*types.Movie{{Title: title}}
+}
+
+func (db *countingDatabase) GetMovie(id int) *types.Movie {
+	db.count("GetMovie")
+	return &types.Movie{ID: id}
+}
+
+func (db *countingDatabase) FindMovies(ids types.ExternalIDs) []*types.Movie {
+	db.count("FindMovies " + ids.IMDB)
+	return []*types.Movie{{Title: ids.IMDB}}
+}
+
+func (db *countingDatabase) SearchTV(title string, year int) []*types.TV {
+	db.count("SearchTV " + title)
+	return []*types.TV{{Title: title}}
+}
+
+func (db *countingDatabase) GetTV(id int, order dbs.Order, series ...int) *types.TV {
+	db.count("GetTV")
+	return &types.TV{ID: id}
+}
+
+func (db *countingDatabase) FindTV(ids types.ExternalIDs) []*types.TV {
+	db.count("FindTV " + ids.IMDB)
+	return []*types.TV{{Title: ids.IMDB}}
+}
+
+//fails every search, after a delay so that concurrent searches wait on it
+type panickingDatabase struct {
+	countingDatabase
+}
+
+func (db *panickingDatabase) SearchTV(title string, year int) []*types.TV {
+	db.count("SearchTV " + title)
+	time.Sleep(10 * time.Millisecond)
+	panic("search failed")
+}
+
+func TestMemo(t *testing.T) {
+
+	var tests = []struct {
+		name     string
+		lookups  func(m *Memo)
+		expected map[string]int
+	}{
+		{
+			name: "Repeated Searches",
+			lookups: func(m *Memo) {
+				m.SearchTV("Broadchurch", 0)
+				m.SearchTV("Broadchurch", 0)
+				m.SearchTV("Broadchurch", 2013) //different query
+				m.SearchMovies("Broadchurch", 0)
+				m.FindTV(types.ExternalIDs{IMDB: "tt2249364"})
+				m.FindTV(types.ExternalIDs{IMDB: "tt2249364"})
+				m.FindMovies(types.ExternalIDs{IMDB: "tt2249364"}) //different lookup
+			},
+			expected: map[string]int{
+				"SearchTV Broadchurch":     2,
+				"SearchMovies Broadchurch": 1,
+				"FindTV tt2249364":         1,
+				"FindMovies tt2249364":     1,
+			},
+		},
+		{
+			name: "Repeated Show Fetches",
+			lookups: func(m *Memo) {
+				m.GetTV(1, dbs.AiredOrder, 2)
+				m.GetTV(1, dbs.AiredOrder, 2)
+				m.GetTV(1, dbs.AiredOrder, 3)
+				m.GetTV(2, dbs.AiredOrder, 2)
+				m.GetTV(1, dbs.DVDOrder, 2) //different order
+				m.GetMovie(1)
+				m.GetMovie(1)
+			},
+			expected: map[string]int{
+				"GetTV":    4,
+				"GetMovie": 1,
+			},
+		},
+		{
+			name: "Concurrent Searches",
+			lookups: func(m *Memo) {
+				var wg sync.WaitGroup
+				for i := 0; i < 50; i++ {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						m.SearchTV("Broadchurch", 0)
+					}()
+				}
+				wg.Wait()
+			},
+			expected: map[string]int{
+				"SearchTV Broadchurch": 1,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		db := &countingDatabase{
+			calls: make(map[string]int),
+		}
+
+		test.lookups(New(db).(*Memo))
+
+		if diff := pretty.Compare(test.expected, db.calls); diff != "" {
+			t.Errorf("%s unexpected diff (-want +got):\n%s", test.name, diff)
+		}
+	}
+}
+
+func TestMemo_Panic(t *testing.T) {
+
+	db := &panickingDatabase{
+		countingDatabase{calls: make(map[string]int)},
+	}
+	m := New(db).(*Memo)
+
+	var mu sync.Mutex
+	panics := 0
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				if recover() != nil {
+					mu.Lock()
+					panics++
+					mu.Unlock()
+				}
+			}()
+			m.SearchTV("Broadchurch", 0)
+		}()
+	}
+
+	//searches waiting on the one that panicked must not block
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("searches blocked after the first panicked")
+	}
+
+	if panics != 50 {
+		t.Errorf("expected 50 searches to panic, got %d", panics)
+	}
+
+	//failed searches aren't remembered
+	calls := db.calls["SearchTV Broadchurch"]
+	func() {
+		defer func() { recover() }()
+		m.SearchTV("Broadchurch", 0)
+	}()
+
+	if db.calls["SearchTV Broadchurch"] != calls+1 {
+		t.Errorf("expected search to be made again after panicking")
+	}
+}
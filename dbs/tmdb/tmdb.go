@@ -1,10 +1,15 @@
 package tmdb
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"net/http"
 	"net/url"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/rustedturnip/media-mapper/dbs"
@@ -14,12 +19,31 @@ import (
 
 const (
 	//movie calls
-	apiMovieSearch = "https://api.themoviedb.org/3/search/movie?api_key=%s&language=en-GB&query=%s&page=1&include_adult=true"
+	apiMovieSearch     = "https://api.themoviedb.org/3/search/movie?api_key=%s&language=en-GB&query=%s&page=1&include_adult=true"
+	apiMovieSearchYear = "&primary_release_year=%d"
+	apiMovieByID       = "https://api.themoviedb.org/3/movie/%d?api_key=%s&language=en-GB"
 
 	//tv calls
-	apiTVSearch       = "https://api.themoviedb.org/3/search/tv?api_key=%s&language=en-GB&query=%s&page=1&include_adult=true"
-	apiTVByID         = "https://api.themoviedb.org/3/tv/%d?api_key=%s&language=en-GB"
-	apiSeriesByNumber = "https://api.themoviedb.org/3/tv/%d/season/%d?api_key=%s&language=en-GB"
+	apiTVSearch     = "https://api.themoviedb.org/3/search/tv?api_key=%s&language=en-GB&query=%s&page=1&include_adult=true"
+	apiTVSearchYear = "&first_air_date_year=%d"
+	apiTVByID       = "https://api.themoviedb.org/3/tv/%d?api_key=%s&language=en-GB"
+
+	//episode groups, other orders of a show's episodes such as its DVD order
+	apiEpisodeGroups     = "https://api.themoviedb.org/3/tv/%d/episode_groups?api_key=%s&language=en-GB"
+	apiEpisodeGroup      = "https://api.themoviedb.org/3/tv/episode_group/%s?api_key=%s&language=en-GB"
+	episodeGroupAbsolute = 2
+	episodeGroupDVD      = 3
+
+	//find calls, by the media's ID in another database
+	apiFind     = "https://api.themoviedb.org/3/find/%s?api_key=%s&language=en-GB&external_source=%s"
+	apiFindIMDB = "imdb_id"
+	apiFindTVDB = "tvdb_id"
+
+	//up to maxAppendToResponse sub-requests (e.g. series) can be added to a request
+	apiAppendToResponse  = "&append_to_response=%s"
+	apiAppendSeries      = "season/%d"
+	apiAppendExternalIDs = "external_ids"
+	maxAppendToResponse  = 20
 
 	apiDateFormat = "2006-01-02"
 )
@@ -36,9 +60,14 @@ func New(key string) dbs.Database {
 	}
 }
 
-func (db *TMDB) SearchMovies(title string) []*types.Movie {
+func (db *TMDB) SearchMovies(title string, year int) []*types.Movie {
 
-	results, err := db.searchMovies(title)
+	results, err := db.searchMovies(title, year)
+
+	//the year may be wrong (e.g. a re-release) so retry without it
+	if err == nil && len(results.Results) == 0 && year != 0 {
+		results, err = db.searchMovies(title, 0)
+	}
 
 	if err != nil {
 		log.Println(fmt.Sprintf("Failed getting Movie results with error: %s", err.Error()))
@@ -47,20 +76,26 @@ func (db *TMDB) SearchMovies(title string) []*types.Movie {
 
 	var movies []*types.Movie
 	for _, movie := range results.Results {
-		movies = append(movies, buildMovie(movie))
+		movies = append(movies, buildMovie(movie, types.ExternalIDs{}))
 	}
 
 	return movies
 }
 
-func (db *TMDB) searchMovies(title string) (*movieSearch, error) {
+func (db *TMDB) searchMovies(title string, year int) (*movieSearch, error) {
 
 	searchQuery := url.QueryEscape(title)
 
-	resp, err := db.httpClient.Get(fmt.Sprintf(apiMovieSearch, db.apiKey, searchQuery))
+	link := fmt.Sprintf(apiMovieSearch, db.apiKey, searchQuery)
+	if year != 0 {
+		link += fmt.Sprintf(apiMovieSearchYear, year)
+	}
+
+	resp, err := db.httpClient.Get(link)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
 	var searchResults *movieSearch
 	err = dbs.ReadJsonToStruct(resp.Body, &searchResults)
@@ -72,7 +107,54 @@ func (db *TMDB) searchMovies(title string) (*movieSearch, error) {
 	return searchResults, nil
 }
 
-func buildMovie(result movieSearchResult) *types.Movie {
+//GetMovie fetches the details of the movie with the specified ID, including
+//its IMDb ID
+func (db *TMDB) GetMovie(id int) *types.Movie {
+
+	resp, err := db.httpClient.Get(fmt.Sprintf(apiMovieByID, id, db.apiKey))
+	if err == nil {
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			err = fmt.Errorf("unexpected response status: %d", resp.StatusCode)
+		}
+	}
+
+	var details *movieDetails
+	if err == nil {
+		err = dbs.ReadJsonToStruct(resp.Body, &details)
+	}
+
+	if err != nil {
+		log.Println(fmt.Sprintf("Failed getting Movie %d with error: %s", id, err.Error()))
+		return nil
+	}
+
+	return buildMovie(details.movieSearchResult, types.ExternalIDs{IMDB: details.ImdbID})
+}
+
+//FindMovies returns the movies with the IMDb ID in ids, as TMDB only finds
+//movies by their IMDb ID
+func (db *TMDB) FindMovies(ids types.ExternalIDs) []*types.Movie {
+
+	if ids.IMDB == "" {
+		return []*types.Movie{} //empty slice
+	}
+
+	results, err := db.find(ids.IMDB, apiFindIMDB)
+	if err != nil {
+		log.Println(fmt.Sprintf("Failed finding Movie %s with error: %s", ids.IMDB, err.Error()))
+		return []*types.Movie{} //empty slice
+	}
+
+	var movies []*types.Movie
+	for _, movie := range results.MovieResults {
+		movies = append(movies, buildMovie(movie, types.ExternalIDs{IMDB: ids.IMDB}))
+	}
+
+	return movies
+}
+
+func buildMovie(result movieSearchResult, ids types.ExternalIDs) *types.Movie {
 
 	movieBuilder := builder.NewMovieBuilder()
 
@@ -82,15 +164,29 @@ func buildMovie(result movieSearchResult) *types.Movie {
 	}
 
 	movie := movieBuilder.
+		WithID(result.ID).
 		WithTitle(result.Title).
+		WithOverview(result.Overview).
 		WithReleaseDate(date).
+		WithPopularity(result.Popularity).
+		WithVoteCount(result.VoteCount).
+		WithPoster(result.PosterPath).
+		WithFanart(result.BackdropPath).
+		WithExternalIDs(ids).
 		Build()
 
 	return movie
 }
 
-func (db *TMDB) SearchTV(title string) []*types.TV {
-	results, err := db.searchTV(title)
+//SearchTV returns the shows matching title, without any of their series
+//which can be fetched for the chosen show with GetTV
+func (db *TMDB) SearchTV(title string, year int) []*types.TV {
+	results, err := db.searchTV(title, year)
+
+	//the year may be wrong (e.g. a revival's) so retry without it
+	if err == nil && len(results.Results) == 0 && year != 0 {
+		results, err = db.searchTV(title, 0)
+	}
 
 	if err != nil {
 		log.Println(fmt.Sprintf("Failed getting TV results with error: %s", err.Error()))
@@ -99,25 +195,26 @@ func (db *TMDB) SearchTV(title string) []*types.TV {
 
 	var shows []*types.TV
 	for _, show := range results.Results {
-
-		showData := db.fetchTVShow(&show)
-		bShow := buildTV(showData)
-		if bShow != nil {
-			shows = append(shows, bShow)
-		}
+		shows = append(shows, buildTVResult(show))
 	}
 
 	return shows
 }
 
-func (db *TMDB) searchTV(title string) (*tvSearch, error) {
+func (db *TMDB) searchTV(title string, year int) (*tvSearch, error) {
 
 	searchQuery := url.QueryEscape(title)
 
-	resp, err := db.httpClient.Get(fmt.Sprintf(apiTVSearch, db.apiKey, searchQuery))
+	link := fmt.Sprintf(apiTVSearch, db.apiKey, searchQuery)
+	if year != 0 {
+		link += fmt.Sprintf(apiTVSearchYear, year)
+	}
+
+	resp, err := db.httpClient.Get(link)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
 	var searchResults *tvSearch
 	err = dbs.ReadJsonToStruct(resp.Body, &searchResults)
@@ -129,41 +226,273 @@ func (db *TMDB) searchTV(title string) (*tvSearch, error) {
 	return searchResults, nil
 }
 
-//fetches all show specific data and returns as tvShow
-func (db *TMDB) fetchTVShow(result *tvSearchResult) *tvShow {
-	errScope := "TV Build error: %s"
+//GetTV fetches the show with the specified ID, populated with only the
+//requested series numbered in the order. Orders other than aired are the
+//show's episode groups, falling back to aired if it has none of the order.
+func (db *TMDB) GetTV(id int, order dbs.Order, series ...int) *types.TV {
 
-	tvResp, err := db.httpClient.Get(fmt.Sprintf(apiTVByID, result.ID, db.apiKey))
+	var group *episodeGroup
+	if order != "" && order != dbs.AiredOrder {
+		var err error
+		if group, err = db.fetchEpisodeGroup(id, order); err != nil {
+			log.Println(fmt.Sprintf("Failed getting %s order of TV show %d with error: %s", order, id, err.Error()))
+			return nil
+		}
+	}
+
+	//the series of episode groups are built from the group instead
+	fetch := series
+	if group != nil {
+		fetch = nil
+	}
+
+	show, err := db.fetchTVShow(id, fetch)
 	if err != nil {
-		log.Println(fmt.Sprintf(errScope, err))
+		log.Println(fmt.Sprintf("Failed getting TV show %d with error: %s", id, err.Error()))
 		return nil
 	}
 
-	var tvObj *tvShow
-	err = dbs.ReadJsonToStruct(tvResp.Body, &tvObj)
+	if group != nil {
+		applyEpisodeGroup(show, group, series)
+	}
+
+	return buildTV(show)
+}
+
+//FindTV returns the shows with the IMDb or TVDB ID in ids, without any of
+//their series. The IMDb ID is tried first.
+func (db *TMDB) FindTV(ids types.ExternalIDs) []*types.TV {
+
+	var sources [][2]string //ID and its source
+	if ids.IMDB != "" {
+		sources = append(sources, [2]string{ids.IMDB, apiFindIMDB})
+	}
+	if ids.TVDB != 0 {
+		sources = append(sources, [2]string{strconv.Itoa(ids.TVDB), apiFindTVDB})
+	}
+
+	shows := []*types.TV{} //empty slice if none are found
+	for _, source := range sources {
+		results, err := db.find(source[0], source[1])
+		if err != nil {
+			log.Println(fmt.Sprintf("Failed finding TV show %s with error: %s", source[0], err.Error()))
+			continue
+		}
+
+		for _, show := range results.TVResults {
+			shows = append(shows, buildTVResult(show))
+		}
+
+		if len(shows) > 0 {
+			break
+		}
+	}
+
+	return shows
+}
+
+//queries the find endpoint with the ID of media in the external source
+func (db *TMDB) find(id, source string) (*findResults, error) {
+
+	resp, err := db.httpClient.Get(fmt.Sprintf(apiFind, url.PathEscape(id), db.apiKey, source))
 	if err != nil {
-		log.Println(fmt.Sprintf(errScope, err))
-		return nil
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response status: %d", resp.StatusCode)
+	}
+
+	var results *findResults
+	if err = dbs.ReadJsonToStruct(resp.Body, &results); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+//fetches show data along with its external IDs and the specified series.
+//These are appended to the show request, in batches of up to
+//maxAppendToResponse, so that only one request is needed for most shows.
+func (db *TMDB) fetchTVShow(id int, series []int) (*tvShow, error) {
+
+	var show *tvShow
+
+	//external IDs are appended to the first request along with the series
+	appends := []string{apiAppendExternalIDs}
+	for _, number := range series {
+		appends = append(appends, fmt.Sprintf(apiAppendSeries, number))
 	}
 
-	for _, s := range tvObj.Seasons {
-		seriesResp, err := db.httpClient.Get(fmt.Sprintf(apiSeriesByNumber, result.ID, s.SeasonNumber, db.apiKey))
+	for start := 0; start < len(appends); start += maxAppendToResponse {
+		end := start + maxAppendToResponse
+		if end > len(appends) {
+			end = len(appends)
+		}
+
+		link := fmt.Sprintf(apiTVByID, id, db.apiKey)
+		link += fmt.Sprintf(apiAppendToResponse, strings.Join(appends[start:end], ","))
+
+		resp, err := db.httpClient.Get(link)
 		if err != nil {
-			log.Println(fmt.Sprintf(errScope, err))
-			return nil
+			return nil, err
+		}
+
+		//closed in each batch, rather than deferred until every batch is fetched
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("unexpected response status: %d", resp.StatusCode)
 		}
 
-		var sObj *tvShowSeriesData
-		err = dbs.ReadJsonToStruct(seriesResp.Body, &sObj)
+		data, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
 		if err != nil {
-			log.Println(fmt.Sprintf(errScope, err))
-			return nil
+			return nil, err
 		}
 
-		s.SeasonData = sObj
+		if show == nil {
+			if err = json.Unmarshal(data, &show); err != nil {
+				return nil, err
+			}
+		}
+
+		//appended series are keyed by their append path, e.g. "season/1"
+		var appended map[string]json.RawMessage
+		if err = json.Unmarshal(data, &appended); err != nil {
+			return nil, err
+		}
+
+		for _, info := range show.Seasons {
+			raw, ok := appended[fmt.Sprintf(apiAppendSeries, info.SeasonNumber)]
+			if !ok {
+				continue
+			}
+
+			if err = json.Unmarshal(raw, &info.SeasonData); err != nil {
+				return nil, err
+			}
+		}
 	}
 
-	return tvObj
+	return show, nil
+}
+
+//fetches the show's episode group of the order, either the first of its type
+//(e.g. DVD) or the group with the order's ID. The group is nil if the show
+//has none of the type.
+func (db *TMDB) fetchEpisodeGroup(id int, order dbs.Order) (*episodeGroup, error) {
+
+	groupID := string(order)
+
+	if order == dbs.DVDOrder || order == dbs.AbsoluteOrder {
+		groupType := episodeGroupDVD
+		if order == dbs.AbsoluteOrder {
+			groupType = episodeGroupAbsolute
+		}
+
+		var groups *episodeGroups
+		if err := db.get(fmt.Sprintf(apiEpisodeGroups, id, db.apiKey), &groups); err != nil {
+			return nil, err
+		}
+
+		groupID = ""
+		for _, group := range groups.Results {
+			if group.Type == groupType {
+				groupID = group.ID
+				break
+			}
+		}
+
+		if groupID == "" {
+			return nil, nil
+		}
+	}
+
+	var group *episodeGroup
+	if err := db.get(fmt.Sprintf(apiEpisodeGroup, url.PathEscape(groupID), db.apiKey), &group); err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+//requests the link, reading the response into obj
+func (db *TMDB) get(link string, obj interface{}) error {
+
+	resp, err := db.httpClient.Get(link)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response status: %d", resp.StatusCode)
+	}
+
+	return dbs.ReadJsonToStruct(resp.Body, obj)
+}
+
+//replaces the show's series with the requested series of the episode group.
+//Groups are numbered by their order, as Jellyfin and Kodi number them, and
+//their episodes by their position in the group from 1.
+func applyEpisodeGroup(show *tvShow, group *episodeGroup, series []int) {
+
+	requested := make(map[int]bool)
+	for _, number := range series {
+		requested[number] = true
+	}
+
+	show.Seasons = nil
+	show.NumberOfSeasons = 0
+
+	for _, g := range group.Groups {
+		if g.Order != 0 {
+			show.NumberOfSeasons++
+		}
+
+		//every series is listed, but only those requested have their data
+		info := &tvShowSeriesInfo{
+			Name:         g.Name,
+			SeasonNumber: g.Order,
+		}
+		show.Seasons = append(show.Seasons, info)
+
+		if !requested[g.Order] {
+			continue
+		}
+
+		info.SeasonData = &tvShowSeriesData{
+			Name:         g.Name,
+			SeasonNumber: g.Order,
+		}
+
+		for _, e := range g.Episodes {
+			episode := e.tvShowSeriesEpisode
+			episode.SeasonNumber = g.Order
+			episode.EpisodeNumber = e.Order + 1
+			info.SeasonData.Episodes = append(info.SeasonData.Episodes, &episode)
+		}
+	}
+}
+
+//builds a search result into types.TV, without any series
+func buildTVResult(result tvSearchResult) *types.TV {
+
+	tvBuilder := builder.NewTVBuilder()
+
+	//first air date is optional for shows yet to air
+	if date, err := time.Parse(apiDateFormat, result.FirstAirDate); err == nil {
+		tvBuilder.WithReleaseDate(date)
+	}
+
+	return tvBuilder.
+		WithID(result.ID).
+		WithTitle(result.Name).
+		WithOverview(result.Overview).
+		WithPopularity(result.Popularity).
+		WithVoteCount(result.VoteCount).
+		Build()
 }
 
 //builds tvShow into types.TV
@@ -171,7 +500,21 @@ func buildTV(show *tvShow) *types.TV {
 	//Build TV Series
 	tvBuilder := builder.NewTVBuilder()
 
+	//series may be numbered by year, so aren't always 1 to the number of seasons
+	var numbers []int
+	for _, sInfo := range show.Seasons {
+		if sInfo.SeasonNumber != 0 {
+			numbers = append(numbers, sInfo.SeasonNumber)
+		}
+	}
+	sort.Ints(numbers)
+	tvBuilder.WithSeriesNumbers(numbers)
+
 	for _, sInfo := range show.Seasons {
+		if sInfo.SeasonData == nil {
+			continue //series wasn't requested
+		}
+
 		//Build Series
 		seriesBuilder := builder.NewSeriesBuilder()
 		for _, e := range sInfo.SeasonData.Episodes {
@@ -179,22 +522,48 @@ func buildTV(show *tvShow) *types.TV {
 			//Build Episode
 			episodeBuilder := builder.NewEpisodeBuilder()
 			episodeBuilder.
+				WithID(e.ID).
 				WithTitle(e.Name).
-				WithNumber(e.EpisodeNumber)
+				WithNumber(e.EpisodeNumber).
+				WithOverview(e.Overview)
+
+			if date, err := time.Parse(apiDateFormat, e.AirDate); err == nil {
+				episodeBuilder.WithAirDate(date)
+			}
 
 			seriesBuilder.WithEpisode(episodeBuilder)
 		}
 
 		seriesBuilder.
+			WithID(sInfo.ID).
 			WithTitle(sInfo.Name).
-			WithNumber(sInfo.SeasonNumber)
+			WithNumber(sInfo.SeasonNumber).
+			WithPoster(sInfo.PosterPath)
 
 		tvBuilder.WithSeries(seriesBuilder)
 	}
 
+	//first air date is optional for shows yet to air
+	if date, err := time.Parse(apiDateFormat, show.FirstAirDate); err == nil {
+		tvBuilder.WithReleaseDate(date)
+	}
+
 	tvBuilder.
+		WithID(show.ID).
 		WithTitle(show.Name).
-		WithSeriesCount(show.NumberOfSeasons)
+		WithOverview(show.Overview).
+		WithSeriesCount(show.NumberOfSeasons).
+		WithPopularity(show.Popularity).
+		WithVoteCount(show.VoteCount).
+		WithPoster(show.PosterPath).
+		WithFanart(show.BackdropPath)
+
+	if show.ExternalIDs != nil {
+		tvBuilder.WithExternalIDs(types.ExternalIDs{
+			IMDB: show.ExternalIDs.ImdbID,
+			TVDB: show.ExternalIDs.TvdbID,
+		})
+	}
 
 	return tvBuilder.Build()
 }
@@ -21,6 +21,7 @@ func TestTMDB_SearchMovies(t *testing.T) {
 	var tests = []struct {
 		name       string
 		titleInput string
+		yearInput  int
 		responses  map[string]*http.Response
 		expected   []*types.Movie
 	}{
@@ -61,8 +62,14 @@ func TestTMDB_SearchMovies(t *testing.T) {
 			},
 			expected: []*types.Movie{
 				{
+					ID:          641,
 					Title:       "Requiem for a Dream",
+					Overview:    "The hopes and dreams of four ambitious people are shattered when their drug addictions begin spiraling out of control. A look into addiction and how it overcomes the mind and body.",
 					ReleaseDate: time.Unix(970790400, 0).UTC(), //2000-10-06
+					Popularity:  8.806,
+					VoteCount:   6623,
+					Poster:      "/nOd6vjEmzCT0k4VYqsA2hwyi87C.jpg",
+					Fanart:      "/c5g1Dn1tF22CS2oOvHDNKr1Ve2U.jpg",
 				},
 			},
 		},
@@ -143,16 +150,113 @@ func TestTMDB_SearchMovies(t *testing.T) {
 			},
 			expected: []*types.Movie{
 				{
+					ID:          121,
 					Title:       "The Lord of the Rings: The Two Towers",
+					Overview:    "Frodo and Sam are trekking to Mordor to destroy the One Ring of Power while Gimli, Legolas and Aragorn search for the orc-captured Merry and Pippin. All along, nefarious wizard Saruman awaits the Fellowship members at the Orthanc Tower in Isengard.",
 					ReleaseDate: time.Unix(1040169600, 0).UTC(),
+					Popularity:  51.45,
+					VoteCount:   15426,
+					Poster:      "/5VTN0pR8gcqV3EPUHHfMGnJYN9L.jpg",
+					Fanart:      "/9BUvLUz1GhbNpcyQRyZm1HNqMq4.jpg",
 				},
 				{
+					ID:          122,
 					Title:       "The Lord of the Rings: The Return of the King",
+					Overview:    "Aragorn is revealed as the heir to the ancient kings as he, Gandalf and the other members of the broken fellowship struggle to save Gondor from Sauron's forces. Meanwhile, Frodo and Sam take the ring closer to the heart of Mordor, the dark lord's realm.",
 					ReleaseDate: time.Unix(1070236800, 0).UTC(),
+					Popularity:  52.865,
+					VoteCount:   16391,
+					Poster:      "/rCzpDGLbOoPwLjy3OAm5NUPOTrC.jpg",
+					Fanart:      "/9DeGfFIqjph5CBFVQrD6wv9S7rR.jpg",
 				},
 				{
+					ID:          120,
 					Title:       "The Lord of the Rings: The Fellowship of the Ring",
+					Overview:    "Young hobbit Frodo Baggins, after inheriting a mysterious ring from his uncle Bilbo, must leave his home in order to keep it from falling into the hands of its evil creator. Along the way, a fellowship is formed to protect the ringbearer and make sure that the ring arrives at its final destination: Mt. Doom, the only place where it can be destroyed.",
 					ReleaseDate: time.Unix(1008633600, 0).UTC(),
+					Popularity:  53.291,
+					VoteCount:   17860,
+					Poster:      "/6oom5QYQ2yQTMJIbnvbkBL9cHo6.jpg",
+					Fanart:      "/vRQnzOn4HjIMX4LBq9nHhFXbsSu.jpg",
+				},
+			},
+		},
+		{
+			name:       "Movie Search With Year",
+			titleInput: "The Lion King",
+			yearInput:  2019,
+			responses: map[string]*http.Response{
+				"https://api.themoviedb.org/3/search/movie?api_key=TEST_TOKEN&language=en-GB&query=The+Lion+King&page=1&include_adult=true&primary_release_year=2019": {
+					StatusCode: http.StatusOK,
+					Body: ioutil.NopCloser(bytes.NewBufferString(`{
+    "page": 1,
+    "total_results": 1,
+    "total_pages": 1,
+    "results": [
+        {
+            "popularity": 40.51,
+            "vote_count": 7745,
+            "id": 420818,
+            "title": "The Lion King",
+            "overview": "Simba idolises his father, King Mufasa, and takes to heart his own royal destiny.",
+            "release_date": "2019-07-12"
+        }
+    ]
+}`)),
+				},
+			},
+			expected: []*types.Movie{
+				{
+					ID:          420818,
+					Title:       "The Lion King",
+					Overview:    "Simba idolises his father, King Mufasa, and takes to heart his own royal destiny.",
+					ReleaseDate: time.Unix(1562889600, 0).UTC(), //2019-07-12
+					Popularity:  40.51,
+					VoteCount:   7745,
+				},
+			},
+		},
+		{
+			name:       "Movie Search With Year - No Results, Fallback Without Year",
+			titleInput: "Requiem for a Dream",
+			yearInput:  2001,
+			responses: map[string]*http.Response{
+				"https://api.themoviedb.org/3/search/movie?api_key=TEST_TOKEN&language=en-GB&query=Requiem+for+a+Dream&page=1&include_adult=true&primary_release_year=2001": {
+					StatusCode: http.StatusOK,
+					Body: ioutil.NopCloser(bytes.NewBufferString(`{
+    "page": 1,
+    "total_results": 0,
+    "total_pages": 0,
+    "results": []
+}`)),
+				},
+				"https://api.themoviedb.org/3/search/movie?api_key=TEST_TOKEN&language=en-GB&query=Requiem+for+a+Dream&page=1&include_adult=true": {
+					StatusCode: http.StatusOK,
+					Body: ioutil.NopCloser(bytes.NewBufferString(`{
+    "page": 1,
+    "total_results": 1,
+    "total_pages": 1,
+    "results": [
+        {
+            "popularity": 8.806,
+            "vote_count": 6623,
+            "id": 641,
+            "title": "Requiem for a Dream",
+            "overview": "The hopes and dreams of four ambitious people are shattered.",
+            "release_date": "2000-10-06"
+        }
+    ]
+}`)),
+				},
+			},
+			expected: []*types.Movie{
+				{
+					ID:          641,
+					Title:       "Requiem for a Dream",
+					Overview:    "The hopes and dreams of four ambitious people are shattered.",
+					ReleaseDate: time.Unix(970790400, 0).UTC(), //2000-10-06
+					Popularity:  8.806,
+					VoteCount:   6623,
 				},
 			},
 		},
@@ -166,8 +270,153 @@ func TestTMDB_SearchMovies(t *testing.T) {
 			httpClient: dbs.NewHttpClient(test.responses),
 		}
 
-		results := db.SearchMovies(test.titleInput)
+		results := db.SearchMovies(test.titleInput, test.yearInput)
+
+		if diff := pretty.Compare(test.expected, results); diff != "" {
+			t.Errorf("%s unexpected diff (-want +got):\n%s", test.name, diff)
+		}
+	}
+}
+
+func TestTMDB_GetMovie(t *testing.T) {
+
+	var tests = []struct {
+		name      string
+		idInput   int
+		responses map[string]*http.Response
+		expected  *types.Movie
+	}{
+		{
+			name:    "Movie With IMDb ID",
+			idInput: 641,
+			responses: map[string]*http.Response{
+				"https://api.themoviedb.org/3/movie/641?api_key=TEST_TOKEN&language=en-GB": {
+					StatusCode: http.StatusOK,
+					Body: ioutil.NopCloser(bytes.NewBufferString(`{
+    "adult": false,
+    "backdrop_path": "/c5g1Dn1tF22CS2oOvHDNKr1Ve2U.jpg",
+    "id": 641,
+    "imdb_id": "tt0180093",
+    "original_language": "en",
+    "original_title": "Requiem for a Dream",
+    "overview": "The hopes and dreams of four ambitious people are shattered when their drug addictions begin spiraling out of control. A look into addiction and how it overcomes the mind and body.",
+    "popularity": 8.806,
+    "poster_path": "/nOd6vjEmzCT0k4VYqsA2hwyi87C.jpg",
+    "release_date": "2000-10-06",
+    "runtime": 102,
+    "title": "Requiem for a Dream",
+    "vote_average": 8,
+    "vote_count": 6623
+}`)),
+				},
+			},
+			expected: &types.Movie{
+				ID:          641,
+				Title:       "Requiem for a Dream",
+				Overview:    "The hopes and dreams of four ambitious people are shattered when their drug addictions begin spiraling out of control. A look into addiction and how it overcomes the mind and body.",
+				ReleaseDate: time.Unix(970790400, 0).UTC(), //2000-10-06
+				Popularity:  8.806,
+				VoteCount:   6623,
+				Poster:      "/nOd6vjEmzCT0k4VYqsA2hwyi87C.jpg",
+				Fanart:      "/c5g1Dn1tF22CS2oOvHDNKr1Ve2U.jpg",
+				ExternalIDs: types.ExternalIDs{IMDB: "tt0180093"},
+			},
+		},
+		{
+			name:      "Movie Not Found",
+			idInput:   1,
+			responses: map[string]*http.Response{},
+			expected:  nil,
+		},
+	}
+
+	for _, test := range tests {
+
+		//test specific db instance
+		db := &TMDB{
+			apiKey:     testAPIToken,
+			httpClient: dbs.NewHttpClient(test.responses), //mocked http client with test's responses to queries
+		}
+
+		//run test
+		result := db.GetMovie(test.idInput)
+		if diff := pretty.Compare(test.expected, result); diff != "" {
+			t.Errorf("%s unexpected diff (-want +got):\n%s", test.name, diff)
+		}
+	}
+}
+
+func TestTMDB_FindMovies(t *testing.T) {
+
+	var tests = []struct {
+		name      string
+		idsInput  types.ExternalIDs
+		responses map[string]*http.Response
+		expected  []*types.Movie
+	}{
+		{
+			name:     "Found by IMDb ID",
+			idsInput: types.ExternalIDs{IMDB: "tt0133093"},
+			responses: map[string]*http.Response{
+				"https://api.themoviedb.org/3/find/tt0133093?api_key=TEST_TOKEN&language=en-GB&external_source=imdb_id": {
+					StatusCode: http.StatusOK,
+					Body: ioutil.NopCloser(bytes.NewBufferString(`{
+    "movie_results": [
+        {
+            "adult": false,
+            "backdrop_path": "/fNG7i7RqMErkcqhohV2a6cV1Ehy.jpg",
+            "id": 603,
+            "original_language": "en",
+            "original_title": "The Matrix",
+            "overview": "Set in the 22nd century, The Matrix tells the story of a computer hacker who joins a group of underground insurgents fighting the vast and powerful computers who now rule the earth.",
+            "poster_path": "/f89U3ADr1oiB1s9GkdPOEpXUk5H.jpg",
+            "release_date": "1999-03-30",
+            "title": "The Matrix",
+            "video": false,
+            "vote_average": 8.1,
+            "vote_count": 18511,
+            "popularity": 44.523
+        }
+    ],
+    "person_results": [],
+    "tv_results": [],
+    "tv_episode_results": [],
+    "tv_season_results": []
+}`)),
+				},
+			},
+			expected: []*types.Movie{
+				{
+					ID:          603,
+					Title:       "The Matrix",
+					Overview:    "Set in the 22nd century, The Matrix tells the story of a computer hacker who joins a group of underground insurgents fighting the vast and powerful computers who now rule the earth.",
+					ReleaseDate: time.Unix(922752000, 0).UTC(), //1999-03-30
+					Popularity:  44.523,
+					VoteCount:   18511,
+					Poster:      "/f89U3ADr1oiB1s9GkdPOEpXUk5H.jpg",
+					Fanart:      "/fNG7i7RqMErkcqhohV2a6cV1Ehy.jpg",
+					ExternalIDs: types.ExternalIDs{IMDB: "tt0133093"},
+				},
+			},
+		},
+		{
+			name:      "No IMDb ID - Not Searched",
+			idsInput:  types.ExternalIDs{TVDB: 79126},
+			responses: map[string]*http.Response{},
+			expected:  []*types.Movie{},
+		},
+	}
+
+	for _, test := range tests {
+
+		//test specific db instance
+		db := &TMDB{
+			apiKey:     testAPIToken,
+			httpClient: dbs.NewHttpClient(test.responses), //mocked http client with test's responses to queries
+		}
 
+		//run test
+		results := db.FindMovies(test.idsInput)
 		if diff := pretty.Compare(test.expected, results); diff != "" {
 			t.Errorf("%s unexpected diff (-want +got):\n%s", test.name, diff)
 		}
@@ -179,11 +428,12 @@ func TestTMDB_SearchTV(t *testing.T) {
 	var tests = []struct {
 		name       string
 		titleInput string
+		yearInput  int
 		responses  map[string]*http.Response
 		expected   []*types.TV
 	}{
 		{
-			name:       "Normal TV Search - Multiple Series, Multiple Episodes",
+			name:       "Normal TV Search - Series Not Fetched",
 			titleInput: "Paradise PD",
 			responses: map[string]*http.Response{
 				"https://api.themoviedb.org/3/search/tv?api_key=TEST_TOKEN&language=en-GB&query=Paradise+PD&page=1&include_adult=true": {
@@ -216,7 +466,129 @@ func TestTMDB_SearchTV(t *testing.T) {
     ]
 }`)),
 				},
-				"https://api.themoviedb.org/3/tv/81983?api_key=TEST_TOKEN&language=en-GB": {
+			},
+			expected: []*types.TV{
+				{
+					ID:          81983,
+					Title:       "Paradise PD",
+					Overview:    "An eager young rookie joins the ragtag small-town police force led by his dad as they bumble, squabble and snort their way through a big drug case.",
+					ReleaseDate: time.Unix(1535673600, 0).UTC(), //2018-08-31
+					Popularity:  15.595,
+					VoteCount:   95,
+					Series:      map[int]*types.Series{},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		//test specific db instance
+		db := &TMDB{
+			apiKey:     testAPIToken,
+			httpClient: dbs.NewHttpClient(test.responses), //mocked http client with test's responses to queries
+		}
+
+		//run test
+		results := db.SearchTV(test.titleInput, test.yearInput)
+		if diff := pretty.Compare(test.expected, results); diff != "" {
+			t.Errorf("%s unexpected diff (-want +got):\n%s", test.name, diff)
+		}
+	}
+}
+
+func TestTMDB_FindTV(t *testing.T) {
+
+	var tests = []struct {
+		name      string
+		idsInput  types.ExternalIDs
+		responses map[string]*http.Response
+		expected  []*types.TV
+	}{
+		{
+			name:     "Not Found by IMDb ID - Found by TVDB ID",
+			idsInput: types.ExternalIDs{IMDB: "tt0306414", TVDB: 79126},
+			responses: map[string]*http.Response{
+				"https://api.themoviedb.org/3/find/tt0306414?api_key=TEST_TOKEN&language=en-GB&external_source=imdb_id": {
+					StatusCode: http.StatusOK,
+					Body: ioutil.NopCloser(bytes.NewBufferString(`{
+    "movie_results": [],
+    "person_results": [],
+    "tv_results": [],
+    "tv_episode_results": [],
+    "tv_season_results": []
+}`)),
+				},
+				"https://api.themoviedb.org/3/find/79126?api_key=TEST_TOKEN&language=en-GB&external_source=tvdb_id": {
+					StatusCode: http.StatusOK,
+					Body: ioutil.NopCloser(bytes.NewBufferString(`{
+    "movie_results": [],
+    "person_results": [],
+    "tv_results": [
+        {
+            "backdrop_path": "/layPSOJGckJv3PXZDIVluMq69mn.jpg",
+            "first_air_date": "2002-06-02",
+            "id": 1438,
+            "name": "The Wire",
+            "original_language": "en",
+            "original_name": "The Wire",
+            "overview": "Told from the points of view of both the Baltimore homicide and narcotics detectives and their targets, the series captures a universe in which the national war on drugs has become a permanent, self-sustaining bureaucracy.",
+            "poster_path": "/4lbclFySvugI51fwsyxBTOm4DqK.jpg",
+            "vote_average": 8.6,
+            "vote_count": 1763,
+            "popularity": 35.614
+        }
+    ],
+    "tv_episode_results": [],
+    "tv_season_results": []
+}`)),
+				},
+			},
+			expected: []*types.TV{
+				{
+					ID:          1438,
+					Title:       "The Wire",
+					Overview:    "Told from the points of view of both the Baltimore homicide and narcotics detectives and their targets, the series captures a universe in which the national war on drugs has become a permanent, self-sustaining bureaucracy.",
+					ReleaseDate: time.Unix(1022976000, 0).UTC(), //2002-06-02
+					Popularity:  35.614,
+					VoteCount:   1763,
+					Series:      map[int]*types.Series{},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+
+		//test specific db instance
+		db := &TMDB{
+			apiKey:     testAPIToken,
+			httpClient: dbs.NewHttpClient(test.responses), //mocked http client with test's responses to queries
+		}
+
+		//run test
+		results := db.FindTV(test.idsInput)
+		if diff := pretty.Compare(test.expected, results); diff != "" {
+			t.Errorf("%s unexpected diff (-want +got):\n%s", test.name, diff)
+		}
+	}
+}
+
+func TestTMDB_GetTV(t *testing.T) {
+
+	var tests = []struct {
+		name        string
+		idInput     int
+		orderInput  dbs.Order
+		seriesInput []int
+		responses   map[string]*http.Response
+		expected    *types.TV
+	}{
+		{
+			name:        "All Series Requested - Appended to One Request",
+			idInput:     81983,
+			seriesInput: []int{1, 2},
+			responses: map[string]*http.Response{
+				"https://api.themoviedb.org/3/tv/81983?api_key=TEST_TOKEN&language=en-GB&append_to_response=external_ids,season/1,season/2": {
 					StatusCode: http.StatusOK,
 					Body: ioutil.NopCloser(bytes.NewBufferString(`{
     "backdrop_path": "/vVlhy5xJPHTJ0pMprsI0zxbrrpM.jpg",
@@ -252,6 +624,10 @@ func TestTMDB_SearchTV(t *testing.T) {
     ],
     "homepage": "https://www.netflix.com/title/80191522",
     "id": 81983,
+    "external_ids": {
+        "imdb_id": "tt5887894",
+        "tvdb_id": 348949
+    },
     "in_production": true,
     "languages": [
         "en"
@@ -327,211 +703,846 @@ func TestTMDB_SearchTV(t *testing.T) {
     "status": "Returning Series",
     "type": "Scripted",
     "vote_average": 8.0,
-    "vote_count": 95
+    "vote_count": 95,
+    "season/1": {
+        "_id": "5b875d8b9251412d49004f82",
+        "air_date": "2018-08-31",
+        "episodes": [
+            {
+                "air_date": "2018-08-31",
+                "episode_number": 1,
+                "id": 1560627,
+                "name": "Welcome to Paradise",
+                "overview": "At 18, Kevin Crawford finally gets a shot at joining the police force run by his dad, just as a new drug dubbed \"argyle meth\" hits the streets.",
+                "production_code": "",
+                "season_number": 1,
+                "show_id": 81983,
+                "still_path": "/nUJwHB4aXvRe2GNRhSbkGRTXfz0.jpg",
+                "vote_average": 0.0,
+                "vote_count": 0,
+                "crew": [],
+                "guest_stars": []
+            },
+            {
+                "air_date": "2018-08-31",
+                "episode_number": 2,
+                "id": 1561112,
+                "name": "Ass on the Line",
+                "overview": "Bullet finds fame and glory in an underground dogfighting ring, and Chief Crawford butts heads with his biggest rival on a maddening homicide case.",
+                "production_code": "",
+                "season_number": 1,
+                "show_id": 81983,
+                "still_path": "/cayB67rEdZeqrQwiaI0uhzHuGGk.jpg",
+                "vote_average": 0.0,
+                "vote_count": 0,
+                "crew": [],
+                "guest_stars": []
+            },
+            {
+                "air_date": "2018-08-31",
+                "episode_number": 3,
+                "id": 1561113,
+                "name": "Black & Blue",
+                "overview": "At Gina's insistence, Fitz starts carrying a gun -- and ignites a national media scandal. Shipped off to a nursing home, Hopson uncovers a conspiracy.",
+                "production_code": "",
+                "season_number": 1,
+                "show_id": 81983,
+                "still_path": "/dvH7qAu1dHOedJTeZvwyN22diNj.jpg",
+                "vote_average": 0.0,
+                "vote_count": 0,
+                "crew": [],
+                "guest_stars": []
+            },
+            {
+                "air_date": "2018-08-31",
+                "episode_number": 4,
+                "id": 1561114,
+                "name": "Karla",
+                "overview": "When Kevin's mom buys him a sleek new talking police car, it's love at first sight. Bullet turns Dusty into a fried-chicken kingpin.",
+                "production_code": "",
+                "season_number": 1,
+                "show_id": 81983,
+                "still_path": "/1T3OQHcoaNmWjcngfViKezV6v1F.jpg",
+                "vote_average": 0.0,
+                "vote_count": 0,
+                "crew": [],
+                "guest_stars": []
+            }
+        ],
+        "name": "Season 1",
+        "overview": "",
+        "id": 108605,
+        "poster_path": "/desSj4kx0y9p61vm9QBE3Wm8GxK.jpg",
+        "season_number": 1
+    },
+    "season/2": {
+        "_id": "5e4e8c5835811d0015509819",
+        "air_date": "2020-03-06",
+        "episodes": [
+            {
+                "air_date": "2020-03-06",
+                "episode_number": 1,
+                "id": 2170009,
+                "name": "Paradise Found",
+                "overview": "As tourists flock to the new, peaceful Paradise, Gina plots to bust Dusty out of prison, Kevin savors his hero status, and Karen plans an execution.",
+                "production_code": "",
+                "season_number": 2,
+                "show_id": 81983,
+                "still_path": "/1yHtLtLBRxv8WcVwKv6Vgx5IOBj.jpg",
+                "vote_average": 0.0,
+                "vote_count": 0,
+                "crew": [],
+                "guest_stars": []
+            },
+            {
+                "air_date": "2020-03-06",
+                "episode_number": 2,
+                "id": 2182152,
+                "name": "Big Ball Energy",
+                "overview": "On Kevin Sucks Day, Fitz hunts down a new meth supplier, the chief discovers Karen's secret fetish, and Kevin vows to defy an embarrassing prediction.",
+                "production_code": "",
+                "season_number": 2,
+                "show_id": 81983,
+                "still_path": "/oGTNDaczIMDKzd9USo3zjlfWo8c.jpg",
+                "vote_average": 0.0,
+                "vote_count": 0,
+                "crew": [],
+                "guest_stars": []
+            },
+            {
+                "air_date": "2020-03-06",
+                "episode_number": 3,
+                "id": 2182153,
+                "name": "Tucker Carlson Is a Huge D**k",
+                "overview": "A rant by Tucker Carlson sparks a war of the sexes, leaving Paradise with two police forces. Fitz's new evil plan is thwarted by Gal-Qaeda.",
+                "production_code": "",
+                "season_number": 2,
+                "show_id": 81983,
+                "still_path": "/d6QscY64YIm55bvjf8YmjOQtHIB.jpg",
+                "vote_average": 0.0,
+                "vote_count": 0,
+                "crew": [],
+                "guest_stars": []
+            },
+            {
+                "air_date": "2020-03-06",
+                "episode_number": 4,
+                "id": 2182154,
+                "name": "Who Ate Wally's Waffles",
+                "overview": "Dusty finds a long-lost sitcom star living in Paradise and sets out to reboot his career. The squad obsesses over Kevin's bathroom habits.",
+                "production_code": "",
+                "season_number": 2,
+                "show_id": 81983,
+                "still_path": "/jYAjNlyjmZrLlUPewhwQ7ZiN6bh.jpg",
+                "vote_average": 0.0,
+                "vote_count": 0,
+                "crew": [],
+                "guest_stars": []
+            }
+        ],
+        "name": "Season 2",
+        "overview": "",
+        "id": 143701,
+        "poster_path": "/ij4M1eGTJHU4UOhqGKQfAXNWxDC.jpg",
+        "season_number": 2
+    }
 }`)),
 				},
-				"https://api.themoviedb.org/3/tv/81983/season/1?api_key=TEST_TOKEN&language=en-GB": {
+			},
+			expected: &types.TV{
+				ID:            81983,
+				Title:         "Paradise PD",
+				Overview:      "An eager young rookie joins the ragtag small-town police force led by his dad as they bumble, squabble and snort their way through a big drug case.",
+				SeriesCount:   2,
+				SeriesNumbers: []int{1, 2},
+				ReleaseDate:   time.Unix(1535673600, 0).UTC(), //2018-08-31
+				Popularity:    15.595,
+				VoteCount:     95,
+				Poster:        "/desSj4kx0y9p61vm9QBE3Wm8GxK.jpg",
+				Fanart:        "/vVlhy5xJPHTJ0pMprsI0zxbrrpM.jpg",
+				ExternalIDs:   types.ExternalIDs{IMDB: "tt5887894", TVDB: 348949},
+				Series: map[int]*types.Series{
+					1: {
+						ID:     108605,
+						Title:  "Season 1",
+						Number: 1,
+						Poster: "/desSj4kx0y9p61vm9QBE3Wm8GxK.jpg",
+						Episodes: map[int]*types.Episode{
+							1: {
+								ID:       1560627,
+								Title:    "Welcome to Paradise",
+								Number:   1,
+								Overview: "At 18, Kevin Crawford finally gets a shot at joining the police force run by his dad, just as a new drug dubbed \"argyle meth\" hits the streets.",
+								AirDate:  time.Unix(1535673600, 0).UTC(), //2018-08-31
+							},
+							2: {
+								ID:       1561112,
+								Title:    "Ass on the Line",
+								Number:   2,
+								Overview: "Bullet finds fame and glory in an underground dogfighting ring, and Chief Crawford butts heads with his biggest rival on a maddening homicide case.",
+								AirDate:  time.Unix(1535673600, 0).UTC(), //2018-08-31
+							},
+							3: {
+								ID:       1561113,
+								Title:    "Black & Blue",
+								Number:   3,
+								Overview: "At Gina's insistence, Fitz starts carrying a gun -- and ignites a national media scandal. Shipped off to a nursing home, Hopson uncovers a conspiracy.",
+								AirDate:  time.Unix(1535673600, 0).UTC(), //2018-08-31
+							},
+							4: {
+								ID:       1561114,
+								Title:    "Karla",
+								Number:   4,
+								Overview: "When Kevin's mom buys him a sleek new talking police car, it's love at first sight. Bullet turns Dusty into a fried-chicken kingpin.",
+								AirDate:  time.Unix(1535673600, 0).UTC(), //2018-08-31
+							},
+						},
+					},
+					2: {
+						ID:     143701,
+						Title:  "Season 2",
+						Number: 2,
+						Poster: "/ij4M1eGTJHU4UOhqGKQfAXNWxDC.jpg",
+						Episodes: map[int]*types.Episode{
+							1: {
+								ID:       2170009,
+								Title:    "Paradise Found",
+								Number:   1,
+								Overview: "As tourists flock to the new, peaceful Paradise, Gina plots to bust Dusty out of prison, Kevin savors his hero status, and Karen plans an execution.",
+								AirDate:  time.Unix(1583452800, 0).UTC(), //2020-03-06
+							},
+							2: {
+								ID:       2182152,
+								Title:    "Big Ball Energy",
+								Number:   2,
+								Overview: "On Kevin Sucks Day, Fitz hunts down a new meth supplier, the chief discovers Karen's secret fetish, and Kevin vows to defy an embarrassing prediction.",
+								AirDate:  time.Unix(1583452800, 0).UTC(), //2020-03-06
+							},
+							3: {
+								ID:       2182153,
+								Title:    "Tucker Carlson Is a Huge D**k",
+								Number:   3,
+								Overview: "A rant by Tucker Carlson sparks a war of the sexes, leaving Paradise with two police forces. Fitz's new evil plan is thwarted by Gal-Qaeda.",
+								AirDate:  time.Unix(1583452800, 0).UTC(), //2020-03-06
+							},
+							4: {
+								ID:       2182154,
+								Title:    "Who Ate Wally's Waffles",
+								Number:   4,
+								Overview: "Dusty finds a long-lost sitcom star living in Paradise and sets out to reboot his career. The squad obsesses over Kevin's bathroom habits.",
+								AirDate:  time.Unix(1583452800, 0).UTC(), //2020-03-06
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:        "Single Series Requested",
+			idInput:     81983,
+			seriesInput: []int{2},
+			responses: map[string]*http.Response{
+				"https://api.themoviedb.org/3/tv/81983?api_key=TEST_TOKEN&language=en-GB&append_to_response=external_ids,season/2": {
 					StatusCode: http.StatusOK,
 					Body: ioutil.NopCloser(bytes.NewBufferString(`{
-    "_id": "5b875d8b9251412d49004f82",
-    "air_date": "2018-08-31",
-    "episodes": [
+    "backdrop_path": "/vVlhy5xJPHTJ0pMprsI0zxbrrpM.jpg",
+    "created_by": [
         {
-            "air_date": "2018-08-31",
-            "episode_number": 1,
-            "id": 1560627,
-            "name": "Welcome to Paradise",
-            "overview": "At 18, Kevin Crawford finally gets a shot at joining the police force run by his dad, just as a new drug dubbed \"argyle meth\" hits the streets.",
-            "production_code": "",
-            "season_number": 1,
-            "show_id": 81983,
-            "still_path": "/nUJwHB4aXvRe2GNRhSbkGRTXfz0.jpg",
-            "vote_average": 0.0,
-            "vote_count": 0,
-            "crew": [],
-            "guest_stars": []
+            "id": 948351,
+            "credit_id": "5b875db60e0a266f34004f48",
+            "name": "Roger Black",
+            "gender": 2,
+            "profile_path": null
         },
         {
-            "air_date": "2018-08-31",
-            "episode_number": 2,
-            "id": 1561112,
-            "name": "Ass on the Line",
-            "overview": "Bullet finds fame and glory in an underground dogfighting ring, and Chief Crawford butts heads with his biggest rival on a maddening homicide case.",
-            "production_code": "",
-            "season_number": 1,
-            "show_id": 81983,
-            "still_path": "/cayB67rEdZeqrQwiaI0uhzHuGGk.jpg",
-            "vote_average": 0.0,
-            "vote_count": 0,
-            "crew": [],
-            "guest_stars": []
+            "id": 1094963,
+            "credit_id": "5b875dbe0e0a266f2b005963",
+            "name": "Waco O'Guin",
+            "gender": 2,
+            "profile_path": null
+        }
+    ],
+    "episode_run_time": [
+        28
+    ],
+    "first_air_date": "2018-08-31",
+    "genres": [
+        {
+            "id": 16,
+            "name": "Animation"
         },
         {
-            "air_date": "2018-08-31",
-            "episode_number": 3,
-            "id": 1561113,
-            "name": "Black & Blue",
-            "overview": "At Gina's insistence, Fitz starts carrying a gun -- and ignites a national media scandal. Shipped off to a nursing home, Hopson uncovers a conspiracy.",
-            "production_code": "",
-            "season_number": 1,
-            "show_id": 81983,
-            "still_path": "/dvH7qAu1dHOedJTeZvwyN22diNj.jpg",
-            "vote_average": 0.0,
-            "vote_count": 0,
-            "crew": [],
-            "guest_stars": []
+            "id": 35,
+            "name": "Comedy"
+        }
+    ],
+    "homepage": "https://www.netflix.com/title/80191522",
+    "id": 81983,
+    "external_ids": {
+        "imdb_id": "tt5887894",
+        "tvdb_id": 348949
+    },
+    "in_production": true,
+    "languages": [
+        "en"
+    ],
+    "last_air_date": "2020-03-06",
+    "last_episode_to_air": {
+        "air_date": "2020-03-06",
+        "episode_number": 8,
+        "id": 2182163,
+        "name": "Operation DD",
+        "overview": "After learning that Fitz isn't quite what he seems, the squad races to stop their real enemy — and save Paradise from a nuclear disaster.",
+        "production_code": "",
+        "season_number": 2,
+        "show_id": 81983,
+        "still_path": "/5sBIxsBbpbtJy29EnK7mhAEfBml.jpg",
+        "vote_average": 0.0,
+        "vote_count": 0
+    },
+    "name": "Paradise PD",
+    "next_episode_to_air": null,
+    "networks": [
+        {
+            "name": "Netflix",
+            "id": 213,
+            "logo_path": "/wwemzKWzjKYJFfCeiB57q3r4Bcm.png",
+            "origin_country": ""
+        }
+    ],
+    "number_of_episodes": 18,
+    "number_of_seasons": 2,
+    "origin_country": [
+        "US"
+    ],
+    "original_language": "en",
+    "original_name": "Paradise PD",
+    "overview": "An eager young rookie joins the ragtag small-town police force led by his dad as they bumble, squabble and snort their way through a big drug case.",
+    "popularity": 15.595,
+    "poster_path": "/desSj4kx0y9p61vm9QBE3Wm8GxK.jpg",
+    "production_companies": [
+        {
+            "id": 86647,
+            "logo_path": null,
+            "name": "Odenkirk Provissiero Entertainment",
+            "origin_country": ""
         },
+        {
+            "id": 30452,
+            "logo_path": "/zmU1ElCS02iL5N7E5MuY4fV7bCX.png",
+            "name": "Bento Box Entertainment",
+            "origin_country": "US"
+        }
+    ],
+    "seasons": [
         {
             "air_date": "2018-08-31",
-            "episode_number": 4,
-            "id": 1561114,
-            "name": "Karla",
-            "overview": "When Kevin's mom buys him a sleek new talking police car, it's love at first sight. Bullet turns Dusty into a fried-chicken kingpin.",
-            "production_code": "",
-            "season_number": 1,
-            "show_id": 81983,
-            "still_path": "/1T3OQHcoaNmWjcngfViKezV6v1F.jpg",
-            "vote_average": 0.0,
-            "vote_count": 0,
-            "crew": [],
-            "guest_stars": []
+            "episode_count": 10,
+            "id": 108605,
+            "name": "Season 1",
+            "overview": "",
+            "poster_path": "/desSj4kx0y9p61vm9QBE3Wm8GxK.jpg",
+            "season_number": 1
+        },
+        {
+            "air_date": "2020-03-06",
+            "episode_count": 8,
+            "id": 143701,
+            "name": "Season 2",
+            "overview": "",
+            "poster_path": "/ij4M1eGTJHU4UOhqGKQfAXNWxDC.jpg",
+            "season_number": 2
         }
     ],
-    "name": "Season 1",
-    "overview": "",
-    "id": 108605,
-    "poster_path": "/desSj4kx0y9p61vm9QBE3Wm8GxK.jpg",
-    "season_number": 1
+    "status": "Returning Series",
+    "type": "Scripted",
+    "vote_average": 8.0,
+    "vote_count": 95,
+    "season/2": {
+        "_id": "5e4e8c5835811d0015509819",
+        "air_date": "2020-03-06",
+        "episodes": [
+            {
+                "air_date": "2020-03-06",
+                "episode_number": 1,
+                "id": 2170009,
+                "name": "Paradise Found",
+                "overview": "As tourists flock to the new, peaceful Paradise, Gina plots to bust Dusty out of prison, Kevin savors his hero status, and Karen plans an execution.",
+                "production_code": "",
+                "season_number": 2,
+                "show_id": 81983,
+                "still_path": "/1yHtLtLBRxv8WcVwKv6Vgx5IOBj.jpg",
+                "vote_average": 0.0,
+                "vote_count": 0,
+                "crew": [],
+                "guest_stars": []
+            },
+            {
+                "air_date": "2020-03-06",
+                "episode_number": 2,
+                "id": 2182152,
+                "name": "Big Ball Energy",
+                "overview": "On Kevin Sucks Day, Fitz hunts down a new meth supplier, the chief discovers Karen's secret fetish, and Kevin vows to defy an embarrassing prediction.",
+                "production_code": "",
+                "season_number": 2,
+                "show_id": 81983,
+                "still_path": "/oGTNDaczIMDKzd9USo3zjlfWo8c.jpg",
+                "vote_average": 0.0,
+                "vote_count": 0,
+                "crew": [],
+                "guest_stars": []
+            },
+            {
+                "air_date": "2020-03-06",
+                "episode_number": 3,
+                "id": 2182153,
+                "name": "Tucker Carlson Is a Huge D**k",
+                "overview": "A rant by Tucker Carlson sparks a war of the sexes, leaving Paradise with two police forces. Fitz's new evil plan is thwarted by Gal-Qaeda.",
+                "production_code": "",
+                "season_number": 2,
+                "show_id": 81983,
+                "still_path": "/d6QscY64YIm55bvjf8YmjOQtHIB.jpg",
+                "vote_average": 0.0,
+                "vote_count": 0,
+                "crew": [],
+                "guest_stars": []
+            },
+            {
+                "air_date": "2020-03-06",
+                "episode_number": 4,
+                "id": 2182154,
+                "name": "Who Ate Wally's Waffles",
+                "overview": "Dusty finds a long-lost sitcom star living in Paradise and sets out to reboot his career. The squad obsesses over Kevin's bathroom habits.",
+                "production_code": "",
+                "season_number": 2,
+                "show_id": 81983,
+                "still_path": "/jYAjNlyjmZrLlUPewhwQ7ZiN6bh.jpg",
+                "vote_average": 0.0,
+                "vote_count": 0,
+                "crew": [],
+                "guest_stars": []
+            }
+        ],
+        "name": "Season 2",
+        "overview": "",
+        "id": 143701,
+        "poster_path": "/ij4M1eGTJHU4UOhqGKQfAXNWxDC.jpg",
+        "season_number": 2
+    }
 }`)),
 				},
-				"https://api.themoviedb.org/3/tv/81983/season/2?api_key=TEST_TOKEN&language=en-GB": {
+			},
+			expected: &types.TV{
+				ID:            81983,
+				Title:         "Paradise PD",
+				Overview:      "An eager young rookie joins the ragtag small-town police force led by his dad as they bumble, squabble and snort their way through a big drug case.",
+				SeriesCount:   2,
+				SeriesNumbers: []int{1, 2},
+				ReleaseDate:   time.Unix(1535673600, 0).UTC(), //2018-08-31
+				Popularity:    15.595,
+				VoteCount:     95,
+				Poster:        "/desSj4kx0y9p61vm9QBE3Wm8GxK.jpg",
+				Fanart:        "/vVlhy5xJPHTJ0pMprsI0zxbrrpM.jpg",
+				ExternalIDs:   types.ExternalIDs{IMDB: "tt5887894", TVDB: 348949},
+				Series: map[int]*types.Series{
+					2: {
+						ID:     143701,
+						Title:  "Season 2",
+						Number: 2,
+						Poster: "/ij4M1eGTJHU4UOhqGKQfAXNWxDC.jpg",
+						Episodes: map[int]*types.Episode{
+							1: {
+								ID:       2170009,
+								Title:    "Paradise Found",
+								Number:   1,
+								Overview: "As tourists flock to the new, peaceful Paradise, Gina plots to bust Dusty out of prison, Kevin savors his hero status, and Karen plans an execution.",
+								AirDate:  time.Unix(1583452800, 0).UTC(), //2020-03-06
+							},
+							2: {
+								ID:       2182152,
+								Title:    "Big Ball Energy",
+								Number:   2,
+								Overview: "On Kevin Sucks Day, Fitz hunts down a new meth supplier, the chief discovers Karen's secret fetish, and Kevin vows to defy an embarrassing prediction.",
+								AirDate:  time.Unix(1583452800, 0).UTC(), //2020-03-06
+							},
+							3: {
+								ID:       2182153,
+								Title:    "Tucker Carlson Is a Huge D**k",
+								Number:   3,
+								Overview: "A rant by Tucker Carlson sparks a war of the sexes, leaving Paradise with two police forces. Fitz's new evil plan is thwarted by Gal-Qaeda.",
+								AirDate:  time.Unix(1583452800, 0).UTC(), //2020-03-06
+							},
+							4: {
+								ID:       2182154,
+								Title:    "Who Ate Wally's Waffles",
+								Number:   4,
+								Overview: "Dusty finds a long-lost sitcom star living in Paradise and sets out to reboot his career. The squad obsesses over Kevin's bathroom habits.",
+								AirDate:  time.Unix(1583452800, 0).UTC(), //2020-03-06
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:        "DVD Order - Series From Episode Group",
+			idInput:     615,
+			orderInput:  dbs.DVDOrder,
+			seriesInput: []int{1},
+			responses: map[string]*http.Response{
+				"https://api.themoviedb.org/3/tv/615/episode_groups?api_key=TEST_TOKEN&language=en-GB": {
 					StatusCode: http.StatusOK,
 					Body: ioutil.NopCloser(bytes.NewBufferString(`{
-    "_id": "5e4e8c5835811d0015509819",
-    "air_date": "2020-03-06",
-    "episodes": [
+    "id": 615,
+    "results": [
         {
-            "air_date": "2020-03-06",
-            "episode_number": 1,
-            "id": 2170009,
-            "name": "Paradise Found",
-            "overview": "As tourists flock to the new, peaceful Paradise, Gina plots to bust Dusty out of prison, Kevin savors his hero status, and Karen plans an execution.",
-            "production_code": "",
-            "season_number": 2,
-            "show_id": 81983,
-            "still_path": "/1yHtLtLBRxv8WcVwKv6Vgx5IOBj.jpg",
-            "vote_average": 0.0,
-            "vote_count": 0,
-            "crew": [],
-            "guest_stars": []
+            "description": "Episodes as they aired in production order.",
+            "episode_count": 140,
+            "group_count": 10,
+            "id": "5acf93e60e0a26346d0000ce",
+            "name": "Production Order",
+            "type": 6
         },
         {
-            "air_date": "2020-03-06",
-            "episode_number": 2,
-            "id": 2182152,
-            "name": "Big Ball Energy",
-            "overview": "On Kevin Sucks Day, Fitz hunts down a new meth supplier, the chief discovers Karen's secret fetish, and Kevin vows to defy an embarrassing prediction.",
-            "production_code": "",
-            "season_number": 2,
-            "show_id": 81983,
-            "still_path": "/oGTNDaczIMDKzd9USo3zjlfWo8c.jpg",
-            "vote_average": 0.0,
-            "vote_count": 0,
-            "crew": [],
-            "guest_stars": []
+            "description": "Episodes as released on DVD.",
+            "episode_count": 140,
+            "group_count": 2,
+            "id": "5b11ba820e0a265847002c6e",
+            "name": "DVD Order",
+            "type": 3
+        }
+    ]
+}`)),
+				},
+				"https://api.themoviedb.org/3/tv/episode_group/5b11ba820e0a265847002c6e?api_key=TEST_TOKEN&language=en-GB": {
+					StatusCode: http.StatusOK,
+					Body: ioutil.NopCloser(bytes.NewBufferString(`{
+    "id": "5b11ba820e0a265847002c6e",
+    "name": "DVD Order",
+    "type": 3,
+    "groups": [
+        {
+            "id": "5b11ba9e0e0a26585100317d",
+            "name": "Volume 1",
+            "order": 1,
+            "episodes": [
+                {
+                    "air_date": "1999-03-28",
+                    "episode_number": 1,
+                    "id": 1205,
+                    "name": "Space Pilot 3000",
+                    "season_number": 1,
+                    "order": 0
+                },
+                {
+                    "air_date": "1999-04-04",
+                    "episode_number": 2,
+                    "id": 1206,
+                    "name": "The Series Has Landed",
+                    "season_number": 1,
+                    "order": 1
+                }
+            ]
         },
         {
-            "air_date": "2020-03-06",
-            "episode_number": 3,
-            "id": 2182153,
-            "name": "Tucker Carlson Is a Huge D**k",
-            "overview": "A rant by Tucker Carlson sparks a war of the sexes, leaving Paradise with two police forces. Fitz's new evil plan is thwarted by Gal-Qaeda.",
-            "production_code": "",
-            "season_number": 2,
-            "show_id": 81983,
-            "still_path": "/d6QscY64YIm55bvjf8YmjOQtHIB.jpg",
-            "vote_average": 0.0,
-            "vote_count": 0,
-            "crew": [],
-            "guest_stars": []
+            "id": "5b11baa70e0a265847002c93",
+            "name": "Volume 2",
+            "order": 2,
+            "episodes": [
+                {
+                    "air_date": "1999-11-21",
+                    "episode_number": 10,
+                    "id": 1224,
+                    "name": "Xmas Story",
+                    "season_number": 2,
+                    "order": 0
+                }
+            ]
+        }
+    ]
+}`)),
+				},
+				"https://api.themoviedb.org/3/tv/615?api_key=TEST_TOKEN&language=en-GB&append_to_response=external_ids": {
+					StatusCode: http.StatusOK,
+					Body: ioutil.NopCloser(bytes.NewBufferString(`{
+    "backdrop_path": "/mNnpUWb8BYrE8jyPvh6E8Jgqo0X.jpg",
+    "first_air_date": "1999-03-28",
+    "id": 615,
+    "name": "Futurama",
+    "number_of_seasons": 10,
+    "overview": "The adventures of a late-20th-century New York City pizza delivery boy, Philip J. Fry, who, after being unwittingly cryogenically frozen for one thousand years, finds employment at Planet Express, an interplanetary delivery company in the retro-futuristic 31st century.",
+    "popularity": 110.5,
+    "poster_path": "/6ZS8SOno6kTmWz4eQ8lX8EBXOMv.jpg",
+    "seasons": [
+        {
+            "air_date": "1999-03-28",
+            "episode_count": 9,
+            "id": 1810,
+            "name": "Season 1",
+            "season_number": 1
+        }
+    ],
+    "vote_count": 3214,
+    "external_ids": {
+        "imdb_id": "tt0149460",
+        "tvdb_id": 73871
+    }
+}`)),
+				},
+			},
+			expected: &types.TV{
+				ID:            615,
+				Title:         "Futurama",
+				Overview:      "The adventures of a late-20th-century New York City pizza delivery boy, Philip J. Fry, who, after being unwittingly cryogenically frozen for one thousand years, finds employment at Planet Express, an interplanetary delivery company in the retro-futuristic 31st century.",
+				SeriesCount:   2,
+				SeriesNumbers: []int{1, 2},
+				ReleaseDate:   time.Unix(922579200, 0).UTC(), //1999-03-28
+				Popularity:    110.5,
+				VoteCount:     3214,
+				Poster:        "/6ZS8SOno6kTmWz4eQ8lX8EBXOMv.jpg",
+				Fanart:        "/mNnpUWb8BYrE8jyPvh6E8Jgqo0X.jpg",
+				ExternalIDs:   types.ExternalIDs{IMDB: "tt0149460", TVDB: 73871},
+				Series: map[int]*types.Series{
+					1: {
+						Title:  "Volume 1",
+						Number: 1,
+						Episodes: map[int]*types.Episode{
+							1: {
+								ID:      1205,
+								Title:   "Space Pilot 3000",
+								Number:  1,
+								AirDate: time.Unix(922579200, 0).UTC(), //1999-03-28
+							},
+							2: {
+								ID:      1206,
+								Title:   "The Series Has Landed",
+								Number:  2,
+								AirDate: time.Unix(923184000, 0).UTC(), //1999-04-04
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:        "Episode Group ID",
+			idInput:     615,
+			orderInput:  "5b11ba820e0a265847002c6e",
+			seriesInput: []int{2},
+			responses: map[string]*http.Response{
+				"https://api.themoviedb.org/3/tv/episode_group/5b11ba820e0a265847002c6e?api_key=TEST_TOKEN&language=en-GB": {
+					StatusCode: http.StatusOK,
+					Body: ioutil.NopCloser(bytes.NewBufferString(`{
+    "id": "5b11ba820e0a265847002c6e",
+    "name": "DVD Order",
+    "type": 3,
+    "groups": [
+        {
+            "id": "5b11ba9e0e0a26585100317d",
+            "name": "Volume 1",
+            "order": 1,
+            "episodes": [
+                {
+                    "air_date": "1999-03-28",
+                    "episode_number": 1,
+                    "id": 1205,
+                    "name": "Space Pilot 3000",
+                    "season_number": 1,
+                    "order": 0
+                },
+                {
+                    "air_date": "1999-04-04",
+                    "episode_number": 2,
+                    "id": 1206,
+                    "name": "The Series Has Landed",
+                    "season_number": 1,
+                    "order": 1
+                }
+            ]
         },
         {
-            "air_date": "2020-03-06",
-            "episode_number": 4,
-            "id": 2182154,
-            "name": "Who Ate Wally's Waffles",
-            "overview": "Dusty finds a long-lost sitcom star living in Paradise and sets out to reboot his career. The squad obsesses over Kevin's bathroom habits.",
-            "production_code": "",
-            "season_number": 2,
-            "show_id": 81983,
-            "still_path": "/jYAjNlyjmZrLlUPewhwQ7ZiN6bh.jpg",
-            "vote_average": 0.0,
-            "vote_count": 0,
-            "crew": [],
-            "guest_stars": []
+            "id": "5b11baa70e0a265847002c93",
+            "name": "Volume 2",
+            "order": 2,
+            "episodes": [
+                {
+                    "air_date": "1999-11-21",
+                    "episode_number": 10,
+                    "id": 1224,
+                    "name": "Xmas Story",
+                    "season_number": 2,
+                    "order": 0
+                }
+            ]
+        }
+    ]
+}`)),
+				},
+				"https://api.themoviedb.org/3/tv/615?api_key=TEST_TOKEN&language=en-GB&append_to_response=external_ids": {
+					StatusCode: http.StatusOK,
+					Body: ioutil.NopCloser(bytes.NewBufferString(`{
+    "backdrop_path": "/mNnpUWb8BYrE8jyPvh6E8Jgqo0X.jpg",
+    "first_air_date": "1999-03-28",
+    "id": 615,
+    "name": "Futurama",
+    "number_of_seasons": 10,
+    "overview": "The adventures of a late-20th-century New York City pizza delivery boy, Philip J. Fry, who, after being unwittingly cryogenically frozen for one thousand years, finds employment at Planet Express, an interplanetary delivery company in the retro-futuristic 31st century.",
+    "popularity": 110.5,
+    "poster_path": "/6ZS8SOno6kTmWz4eQ8lX8EBXOMv.jpg",
+    "seasons": [
+        {
+            "air_date": "1999-03-28",
+            "episode_count": 9,
+            "id": 1810,
+            "name": "Season 1",
+            "season_number": 1
         }
     ],
-    "name": "Season 2",
-    "overview": "",
-    "id": 143701,
-    "poster_path": "/ij4M1eGTJHU4UOhqGKQfAXNWxDC.jpg",
-    "season_number": 2
+    "vote_count": 3214,
+    "external_ids": {
+        "imdb_id": "tt0149460",
+        "tvdb_id": 73871
+    }
 }`)),
 				},
 			},
-			expected: []*types.TV{
-				{
-					Title:       "Paradise PD",
-					SeriesCount: 2,
-					Series: map[int]*types.Series{
-						1: {
-							Title:  "Season 1",
-							Number: 1,
-							Episodes: map[int]*types.Episode{
-								1: {
-									Title:  "Welcome to Paradise",
-									Number: 1,
-								},
-								2: {
-									Title:  "Ass on the Line",
-									Number: 2,
-								},
-								3: {
-									Title:  "Black & Blue",
-									Number: 3,
-								},
-								4: {
-									Title:  "Karla",
-									Number: 4,
-								},
+			expected: &types.TV{
+				ID:            615,
+				Title:         "Futurama",
+				Overview:      "The adventures of a late-20th-century New York City pizza delivery boy, Philip J. Fry, who, after being unwittingly cryogenically frozen for one thousand years, finds employment at Planet Express, an interplanetary delivery company in the retro-futuristic 31st century.",
+				SeriesCount:   2,
+				SeriesNumbers: []int{1, 2},
+				ReleaseDate:   time.Unix(922579200, 0).UTC(), //1999-03-28
+				Popularity:    110.5,
+				VoteCount:     3214,
+				Poster:        "/6ZS8SOno6kTmWz4eQ8lX8EBXOMv.jpg",
+				Fanart:        "/mNnpUWb8BYrE8jyPvh6E8Jgqo0X.jpg",
+				ExternalIDs:   types.ExternalIDs{IMDB: "tt0149460", TVDB: 73871},
+				Series: map[int]*types.Series{
+					2: {
+						Title:  "Volume 2",
+						Number: 2,
+						Episodes: map[int]*types.Episode{
+							1: {
+								ID:      1224,
+								Title:   "Xmas Story",
+								Number:  1,
+								AirDate: time.Unix(943142400, 0).UTC(), //1999-11-21
 							},
 						},
-						2: {
-							Title:  "Season 2",
-							Number: 2,
-							Episodes: map[int]*types.Episode{
-								1: {
-									Title:  "Paradise Found",
-									Number: 1,
-								},
-								2: {
-									Title:  "Big Ball Energy",
-									Number: 2,
-								},
-								3: {
-									Title:  "Tucker Carlson Is a Huge D**k",
-									Number: 3,
-								},
-								4: {
-									Title:  "Who Ate Wally's Waffles",
-									Number: 4,
-								},
+					},
+				},
+			},
+		},
+		{
+			name:        "Absolute Order - No Episode Group, Aired Order Used",
+			idInput:     615,
+			orderInput:  dbs.AbsoluteOrder,
+			seriesInput: []int{1},
+			responses: map[string]*http.Response{
+				"https://api.themoviedb.org/3/tv/615/episode_groups?api_key=TEST_TOKEN&language=en-GB": {
+					StatusCode: http.StatusOK,
+					Body: ioutil.NopCloser(bytes.NewBufferString(`{
+    "id": 615,
+    "results": [
+        {
+            "description": "Episodes as they aired in production order.",
+            "episode_count": 140,
+            "group_count": 10,
+            "id": "5acf93e60e0a26346d0000ce",
+            "name": "Production Order",
+            "type": 6
+        },
+        {
+            "description": "Episodes as released on DVD.",
+            "episode_count": 140,
+            "group_count": 2,
+            "id": "5b11ba820e0a265847002c6e",
+            "name": "DVD Order",
+            "type": 3
+        }
+    ]
+}`)),
+				},
+				"https://api.themoviedb.org/3/tv/615?api_key=TEST_TOKEN&language=en-GB&append_to_response=external_ids,season/1": {
+					StatusCode: http.StatusOK,
+					Body: ioutil.NopCloser(bytes.NewBufferString(`{
+    "backdrop_path": "/mNnpUWb8BYrE8jyPvh6E8Jgqo0X.jpg",
+    "first_air_date": "1999-03-28",
+    "id": 615,
+    "name": "Futurama",
+    "number_of_seasons": 10,
+    "overview": "The adventures of a late-20th-century New York City pizza delivery boy, Philip J. Fry, who, after being unwittingly cryogenically frozen for one thousand years, finds employment at Planet Express, an interplanetary delivery company in the retro-futuristic 31st century.",
+    "popularity": 110.5,
+    "poster_path": "/6ZS8SOno6kTmWz4eQ8lX8EBXOMv.jpg",
+    "seasons": [
+        {
+            "air_date": "1999-03-28",
+            "episode_count": 9,
+            "id": 1810,
+            "name": "Season 1",
+            "season_number": 1
+        }
+    ],
+    "vote_count": 3214,
+    "external_ids": {
+        "imdb_id": "tt0149460",
+        "tvdb_id": 73871
+    },
+    "season/1": {
+        "air_date": "1999-03-28",
+        "episodes": [
+            {
+                "air_date": "1999-03-28",
+                "episode_number": 1,
+                "id": 1205,
+                "name": "Space Pilot 3000",
+                "season_number": 1
+            }
+        ],
+        "id": 1810,
+        "name": "Season 1",
+        "season_number": 1
+    }
+}`)),
+				},
+			},
+			expected: &types.TV{
+				ID:            615,
+				Title:         "Futurama",
+				Overview:      "The adventures of a late-20th-century New York City pizza delivery boy, Philip J. Fry, who, after being unwittingly cryogenically frozen for one thousand years, finds employment at Planet Express, an interplanetary delivery company in the retro-futuristic 31st century.",
+				SeriesCount:   10,
+				SeriesNumbers: []int{1},
+				ReleaseDate:   time.Unix(922579200, 0).UTC(), //1999-03-28
+				Popularity:    110.5,
+				VoteCount:     3214,
+				Poster:        "/6ZS8SOno6kTmWz4eQ8lX8EBXOMv.jpg",
+				Fanart:        "/mNnpUWb8BYrE8jyPvh6E8Jgqo0X.jpg",
+				ExternalIDs:   types.ExternalIDs{IMDB: "tt0149460", TVDB: 73871},
+				Series: map[int]*types.Series{
+					1: {
+						ID:     1810,
+						Title:  "Season 1",
+						Number: 1,
+						Episodes: map[int]*types.Episode{
+							1: {
+								ID:      1205,
+								Title:   "Space Pilot 3000",
+								Number:  1,
+								AirDate: time.Unix(922579200, 0).UTC(), //1999-03-28
 							},
 						},
 					},
 				},
 			},
 		},
+		{
+			name:        "Unknown Show",
+			idInput:     1,
+			seriesInput: []int{1},
+			responses:   map[string]*http.Response{},
+			expected:    nil,
+		},
 	}
 
 	for _, test := range tests {
@@ -542,8 +1553,8 @@ func TestTMDB_SearchTV(t *testing.T) {
 		}
 
 		//run test
-		results := db.SearchTV(test.titleInput)
-		if diff := pretty.Compare(test.expected, results); diff != "" {
+		result := db.GetTV(test.idInput, test.orderInput, test.seriesInput...)
+		if diff := pretty.Compare(test.expected, result); diff != "" {
 			t.Errorf("%s unexpected diff (-want +got):\n%s", test.name, diff)
 		}
 	}
@@ -24,6 +24,18 @@ type movieSearchResult struct {
 	ReleaseDate      string  `json:"release_date"`
 }
 
+//details of a single movie, with more than its search result
+type movieDetails struct {
+	movieSearchResult
+	ImdbID string `json:"imdb_id"`
+}
+
+//media found by its ID in another database
+type findResults struct {
+	MovieResults []movieSearchResult `json:"movie_results"`
+	TVResults    []tvSearchResult    `json:"tv_results"`
+}
+
 type tvSearch struct {
 	Page         int              `json:"page"`
 	TotalResults int              `json:"total_results"`
@@ -32,17 +44,33 @@ type tvSearch struct {
 }
 
 type tvSearchResult struct {
-	ID           int    `json:"id"`
-	Name         string `json:"name"`
-	OriginalName string `json:"original_name"`
+	ID           int     `json:"id"`
+	Name         string  `json:"name"`
+	OriginalName string  `json:"original_name"`
+	Overview     string  `json:"overview"`
+	FirstAirDate string  `json:"first_air_date"`
+	Popularity   float64 `json:"popularity"`
+	VoteCount    int     `json:"vote_count"`
 }
 
 type tvShow struct {
 	ID               int                 `json:"id"`
 	Name             string              `json:"name"`
+	PosterPath       string              `json:"poster_path"`
+	BackdropPath     string              `json:"backdrop_path"`
+	Overview         string              `json:"overview"`
+	FirstAirDate     string              `json:"first_air_date"`
+	Popularity       float64             `json:"popularity"`
+	VoteCount        int                 `json:"vote_count"`
 	NumberOfEpisodes int                 `json:"number_of_episodes"`
 	NumberOfSeasons  int                 `json:"number_of_seasons"`
 	Seasons          []*tvShowSeriesInfo `json:"seasons"`
+	ExternalIDs      *tvExternalIDs      `json:"external_ids"` //appended to the request
+}
+
+type tvExternalIDs struct {
+	ImdbID string `json:"imdb_id"`
+	TvdbID int    `json:"tvdb_id"`
 }
 
 //info about the series as a whole
@@ -77,3 +105,34 @@ type tvShowSeriesEpisode struct {
 	ProductionCode string `json:"production_code"`
 	SeasonNumber   int    `json:"season_number"`
 }
+
+//episode groups of a show, other orders of its episodes
+type episodeGroups struct {
+	Results []episodeGroupInfo `json:"results"`
+}
+
+type episodeGroupInfo struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type int    `json:"type"` //e.g. 3 for DVD order
+}
+
+//episodes of a show arranged in groups, each of which is a series
+type episodeGroup struct {
+	ID     string                `json:"id"`
+	Name   string                `json:"name"`
+	Type   int                   `json:"type"`
+	Groups []*episodeGroupSeries `json:"groups"`
+}
+
+type episodeGroupSeries struct {
+	ID       string                 `json:"id"`
+	Name     string                 `json:"name"`
+	Order    int                    `json:"order"`
+	Episodes []*episodeGroupEpisode `json:"episodes"`
+}
+
+type episodeGroupEpisode struct {
+	tvShowSeriesEpisode
+	Order int `json:"order"` //position in the group, from 0
+}
@@ -13,6 +13,7 @@ func TestTMDB_buildMovie(t *testing.T) {
 	var tests = []struct {
 		name     string
 		input    movieSearchResult
+		ids      types.ExternalIDs
 		expected types.Movie
 	}{
 		{
@@ -26,10 +27,25 @@ func TestTMDB_buildMovie(t *testing.T) {
 				ReleaseDate: time.Unix(970790400, 0).UTC(), //2000-10-06
 			},
 		},
+		{
+			name: "Movie with External IDs",
+			input: movieSearchResult{
+				ID:          641,
+				Title:       "Requiem for a Dream",
+				ReleaseDate: "2000-10-06",
+			},
+			ids: types.ExternalIDs{IMDB: "tt0180093"},
+			expected: types.Movie{
+				ID:          641,
+				Title:       "Requiem for a Dream",
+				ReleaseDate: time.Unix(970790400, 0).UTC(), //2000-10-06
+				ExternalIDs: types.ExternalIDs{IMDB: "tt0180093"},
+			},
+		},
 	}
 
 	for _, test := range tests {
-		result := buildMovie(test.input)
+		result := buildMovie(test.input, test.ids)
 
 		if diff := pretty.Compare(test.expected, *result); diff != "" {
 			t.Errorf("%s unexpected diff (-want +got):\n%s", test.name, diff)
@@ -97,8 +113,10 @@ func TestTMDB_buildTV(t *testing.T) {
 				},
 			},
 			expected: types.TV{
-				Title:       "Black Sails",
-				SeriesCount: 2,
+				ID:            47665,
+				Title:         "Black Sails",
+				SeriesCount:   2,
+				SeriesNumbers: []int{1, 2},
 				Series: map[int]*types.Series{
 					1: {
 						Title:  "Season 1",
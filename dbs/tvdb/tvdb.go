@@ -7,7 +7,9 @@ import (
 	"log"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
+	"time"
 
 	"github.com/rustedturnip/media-mapper/dbs"
 	"github.com/rustedturnip/media-mapper/types"
@@ -15,22 +17,26 @@ import (
 )
 
 const (
-	apiBase               = "https://api.thetvdb.com"
-	apiLogin              = "/login"
-	apiSeriesSearch       = "/search/series"
-	apiSeriesByID         = "/series/%d"
-	apiEpisodesBySeriesID = "/series/%d/episodes"
+	apiBase            = "https://api.thetvdb.com"
+	apiLogin           = "/login"
+	apiSeriesSearch    = "/search/series"
+	apiSeriesByID      = "/series/%d"
+	apiEpisodes        = "/series/%d/episodes"
+	apiEpisodesQuery   = "/series/%d/episodes/query"
+	apiEpisodesSummary = "/series/%d/episodes/summary"
 
 	httpHeaderAuth = "Authorization"
 
+	apiDateFormat = "2006-1-2" //TVDB doesn't consistently zero pad dates
+
 	specialEpisodes = 0
 )
 
 type TVDB struct {
-	auth            auth //details used to get token
-	token           string
-	requestTemplate http.Request //used so only URL needs adding in future requests
-	httpClient      *http.Client
+	auth       auth //details used to get token
+	token      string
+	header     http.Header //added to every request, not modified after New so safe for concurrent use
+	httpClient *http.Client
 }
 
 func New(apiKey, username, userkey string) (dbs.Database, error) {
@@ -41,10 +47,7 @@ func New(apiKey, username, userkey string) (dbs.Database, error) {
 			Username: username,
 			UserKey:  userkey,
 		},
-		requestTemplate: http.Request{
-			Method: http.MethodGet,
-			Header: http.Header{},
-		},
+		header:     http.Header{},
 		httpClient: &http.Client{},
 	}
 
@@ -58,6 +61,7 @@ func New(apiKey, username, userkey string) (dbs.Database, error) {
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
 	var token *token
 	err = dbs.ReadJsonToStruct(resp.Body, &token)
@@ -66,19 +70,41 @@ func New(apiKey, username, userkey string) (dbs.Database, error) {
 	}
 
 	tvdb.token = token.Token
-	tvdb.requestTemplate.Header.Add(httpHeaderAuth, fmt.Sprintf("Bearer %s", tvdb.token))
+	tvdb.header.Add(httpHeaderAuth, fmt.Sprintf("Bearer %s", tvdb.token))
 
 	return tvdb, nil
 }
 
+//creates a GET request for the api path with the auth header set. A new
+//request is created each time so that lookups can be made concurrently.
+func (db *TVDB) newRequest(path string, query url.Values) (*http.Request, error) {
+
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s%s", apiBase, path), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, values := range db.header {
+		req.Header[key] = values
+	}
+
+	if query != nil {
+		req.URL.RawQuery = query.Encode()
+	}
+
+	return req, nil
+}
+
 //v3 of the TVDB API doesn't support movie search
 //TODO - implement v4 when available
-func (db *TVDB) SearchMovies(title string) []*types.Movie {
+func (db *TVDB) SearchMovies(title string, year int) []*types.Movie {
 
 	return nil
 }
 
-func (db *TVDB) SearchTV(title string) []*types.TV {
+//SearchTV returns the shows matching title, without any of their series
+//which can be fetched for the chosen show with GetTV
+func (db *TVDB) SearchTV(title string, year int) []*types.TV {
 
 	searchResults, err := db.searchTV(title)
 	if err != nil {
@@ -88,42 +114,89 @@ func (db *TVDB) SearchTV(title string) []*types.TV {
 
 	//compile list of shows (built to *type.TV)
 	var shows []*types.TV
-	for _, show := range searchResults.Results {
+	for _, show := range filterByYear(searchResults.Results, year) {
+		shows = append(shows, buildTVResult(show))
+	}
 
-		data, err := db.fetchTV(show)
-		if err != nil {
-			log.Println(err.Error())
-			continue
-		}
+	return shows
+}
 
-		if bShow := buildTV(data); bShow != nil {
-			shows = append(shows, bShow)
-		}
+//GetMovie returns nil, as TVDB has no movies
+func (db *TVDB) GetMovie(id int) *types.Movie {
+	return nil
+}
+
+//FindMovies returns nil, as TVDB has no movies
+func (db *TVDB) FindMovies(ids types.ExternalIDs) []*types.Movie {
+	return nil
+}
+
+//FindTV returns the shows with the IMDb ID in ids, without any of their
+//series. v3 of the TVDB API can't find shows by their TMDB ID.
+func (db *TVDB) FindTV(ids types.ExternalIDs) []*types.TV {
+
+	if ids.IMDB == "" {
+		return []*types.TV{} //empty slice
+	}
+
+	q := url.Values{}
+	q.Set("imdbId", ids.IMDB)
+
+	searchResults, err := db.search(q)
+	if err != nil {
+		log.Println(fmt.Sprintf("Failed finding TV show %s with error: %s", ids.IMDB, err.Error()))
+		return []*types.TV{} //empty slice
+	}
+
+	var shows []*types.TV
+	for _, show := range searchResults.Results {
+		shows = append(shows, buildTVResult(show))
 	}
 
 	return shows
 }
 
-//queries search endpoint with specified title
-func (db *TVDB) searchTV(title string) (*tvSearch, error) {
+//GetTV fetches the show with the specified ID, populated with only the
+//requested series numbered in the order. Episode groups are TMDB's, so
+//shows are numbered as they aired instead.
+func (db *TVDB) GetTV(id int, order dbs.Order, series ...int) *types.TV {
 
-	req := &db.requestTemplate
+	if order != dbs.DVDOrder && order != dbs.AbsoluteOrder {
+		order = dbs.AiredOrder
+	}
 
-	if link, err := url.Parse(fmt.Sprintf("%s%s", apiBase, apiSeriesSearch)); err == nil {
-		req.URL = link
-	} else {
-		return nil, err
+	data, err := db.fetchTV(uint64(id), order, series)
+	if err != nil {
+		log.Println(fmt.Sprintf("Failed getting TV show %d with error: %s", id, err.Error()))
+		return nil
 	}
 
+	return buildTV(data, order)
+}
+
+//queries search endpoint with specified title
+func (db *TVDB) searchTV(title string) (*tvSearch, error) {
+
 	//add api call parameters
-	q := req.URL.Query()
+	q := url.Values{}
 	q.Set("name", title)
-	req.URL.RawQuery = q.Encode()
+
+	return db.search(q)
+}
+
+//queries search endpoint with the parameters in q, e.g. name
+func (db *TVDB) search(q url.Values) (*tvSearch, error) {
+
+	req, err := db.newRequest(apiSeriesSearch, q)
+	if err != nil {
+		return nil, err
+	}
 
 	resp, err := db.httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
 	var searchResults *tvSearch
 	err = dbs.ReadJsonToStruct(resp.Body, &searchResults)
@@ -134,18 +207,48 @@ func (db *TVDB) searchTV(title string) (*tvSearch, error) {
 	return searchResults, nil
 }
 
-//queries series by ID to get series data
-func (db *TVDB) fetchTV(result *tvSearchResult) (*tvShow, error) {
+//narrows search results to the shows first aired in year, the search
+//endpoint doesn't support filtering so it's done here instead. If the year
+//is unknown or no shows match it, all results are returned.
+func filterByYear(results []*tvSearchResult, year int) []*tvSearchResult {
+
+	if year == 0 {
+		return results
+	}
+
+	var filtered []*tvSearchResult
+	for _, result := range results {
+		if date, err := time.Parse(apiDateFormat, result.FirstAired); err == nil && date.Year() == year {
+			filtered = append(filtered, result)
+		}
+	}
+
+	if len(filtered) == 0 {
+		return results
+	}
+
+	return filtered
+}
+
+//queries series by ID to get show data, along with the episodes of the
+//specified series in the order
+func (db *TVDB) fetchTV(id uint64, order dbs.Order, series []int) (*tvShow, error) {
 
 	//fetch show data
-	req := &db.requestTemplate
-	url, _ := url.Parse(fmt.Sprintf("%s%s", apiBase, fmt.Sprintf(apiSeriesByID, result.ID)))
-	req.URL = url
+	req, err := db.newRequest(fmt.Sprintf(apiSeriesByID, id), nil)
+	if err != nil {
+		return nil, err
+	}
 
 	resp, err := db.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error requesting tv show - %s", err.Error())
 	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error requesting tv show - unexpected response status: %d", resp.StatusCode)
+	}
 
 	var tv *tv = &tv{}
 	err = dbs.ReadJsonToStruct(resp.Body, &tv)
@@ -154,31 +257,100 @@ func (db *TVDB) fetchTV(result *tvSearchResult) (*tvShow, error) {
 		return nil, fmt.Errorf("error reading tv response - %s", err.Error())
 	}
 
-	//fetch show episodes
-	episodes, err := db.getEpisodes(result.ID)
-	if err != nil {
-		return nil, fmt.Errorf("error retrieving episodes - %s", err.Error())
+	//absolute order has a single series, otherwise the numbers of the series
+	//are summarised, as they may be numbered by year
+	if order != dbs.AbsoluteOrder {
+		if tv.Show.Summary, err = db.getSummary(id); err != nil {
+			return nil, fmt.Errorf("error retrieving series - %s", err.Error())
+		}
 	}
 
-	tv.Show.Series = &tvSeriesEpisodes{
-		Episodes: episodes,
+	//fetch episodes of requested series
+	tv.Show.Series = &tvSeriesEpisodes{}
+	for _, number := range series {
+		episodes, err := db.getEpisodes(id, order, number)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving episodes - %s", err.Error())
+		}
+
+		tv.Show.Series.Episodes = append(tv.Show.Series.Episodes, episodes...)
 	}
 
 	return tv.Show, nil
 }
 
-//queries for episodes pertaining to series (by series ID)
-func (db *TVDB) getEpisodes(seriesID uint64) ([]*episode, error) {
+//queries for the summary of a show's episodes, listing its series
+func (db *TVDB) getSummary(showID uint64) (*episodesSummary, error) {
+
+	req, err := db.newRequest(fmt.Sprintf(apiEpisodesSummary, showID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := db.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response status: %d", resp.StatusCode)
+	}
+
+	var summary *episodesSummaryResponse = &episodesSummaryResponse{}
+	if err = dbs.ReadJsonToStruct(resp.Body, &summary); err != nil {
+		return nil, err
+	}
+
+	return summary.Summary, nil
+}
+
+//returns the numbers of the show's series in the order, specials excluded
+func seriesNumbers(show *tvShow, order dbs.Order) []int {
+
+	if order == dbs.AbsoluteOrder {
+		return []int{1}
+	}
+	if show.Summary == nil {
+		return nil
+	}
+
+	seasons := show.Summary.AiredSeasons
+	if order == dbs.DVDOrder {
+		seasons = show.Summary.DVDSeasons
+	}
+
+	var numbers []int
+	for _, season := range seasons {
+		if number, err := strconv.Atoi(season); err == nil && number != specialEpisodes {
+			numbers = append(numbers, number)
+		}
+	}
+	sort.Ints(numbers)
+
+	return numbers
+}
+
+//queries for episodes of a single series of a show (by show ID), numbered
+//in the order. Episodes without a number in the order are left out.
+func (db *TVDB) getEpisodes(showID uint64, order dbs.Order, series int) ([]*episode, error) {
 
 	var results []*episode
 
 	nextPage := 1
-	req := &db.requestTemplate
 
-	if link, err := url.Parse(fmt.Sprintf("%s%s", apiBase, fmt.Sprintf(apiEpisodesBySeriesID, seriesID))); err == nil {
-		req.URL = link
-	} else {
-		return nil, err
+	path := fmt.Sprintf(apiEpisodesQuery, showID)
+	q := url.Values{}
+
+	switch {
+	case series == specialEpisodes || order == dbs.AiredOrder:
+		q.Set("airedSeason", strconv.Itoa(series))
+	case order == dbs.DVDOrder:
+		q.Set("dvdSeason", strconv.Itoa(series))
+	case series == 1: //absolute order has a single series of every episode
+		path = fmt.Sprintf(apiEpisodes, showID)
+	default:
+		return results, nil
 	}
 
 	for {
@@ -186,61 +358,144 @@ func (db *TVDB) getEpisodes(seriesID uint64) ([]*episode, error) {
 			break
 		}
 
-		q := req.URL.Query()
 		q.Set("page", strconv.Itoa(nextPage))
-		req.URL.RawQuery = q.Encode()
+
+		req, err := db.newRequest(path, q)
+		if err != nil {
+			return nil, err
+		}
 
 		resp, err := db.httpClient.Do(req)
 		if err != nil {
 			return nil, err //if error, discard all
 		}
 
+		//closed for each page, rather than deferred until every page is fetched
+		if resp.StatusCode == http.StatusNotFound {
+			resp.Body.Close()
+			break //no (more) episodes in series
+		}
+
 		var episodeResults *tvSeriesEpisodes = &tvSeriesEpisodes{}
 		err = dbs.ReadJsonToStruct(resp.Body, &episodeResults)
+		resp.Body.Close()
 		if err != nil {
 			return nil, err //if error, discard all
 		}
 
-		results = append(results, episodeResults.Episodes...)
+		for _, episode := range episodeResults.Episodes {
+			if s, _, ok := episodeNumber(episode, order); ok && s == series {
+				results = append(results, episode)
+			}
+		}
 		nextPage = episodeResults.Links.Next
 	}
 
 	return results, nil
 }
 
-//builds types.TV object based on json structs built from api responses
-func buildTV(show *tvShow) *types.TV {
+//returns the series and number of the episode in the order, and whether
+//it's numbered in it at all. Specials are numbered as they aired.
+func episodeNumber(episode *episode, order dbs.Order) (int, int, bool) {
+
+	switch {
+	case episode.AiredSeason == specialEpisodes || order == dbs.AiredOrder:
+		return episode.AiredSeason, episode.AiredEpisodeNumber, true
+	case order == dbs.DVDOrder && episode.DvdEpisodeNumber != 0:
+		return episode.DvdSeason, episode.DvdEpisodeNumber, true
+	case order == dbs.AbsoluteOrder && episode.AbsoluteNumber != 0:
+		return 1, episode.AbsoluteNumber, true
+	}
+
+	return 0, 0, false
+}
+
+//builds a search result into types.TV, without any series
+func buildTVResult(result *tvSearchResult) *types.TV {
+
+	tvb := builder.NewTVBuilder()
+
+	//first aired is optional for shows yet to air
+	if date, err := time.Parse(apiDateFormat, result.FirstAired); err == nil {
+		tvb.WithReleaseDate(date)
+	}
+
+	return tvb.
+		WithID(int(result.ID)).
+		WithTitle(result.SeriesName).
+		WithOverview(result.Overview).
+		Build()
+}
+
+//builds types.TV object based on json structs built from api responses,
+//numbering the episodes in the order
+func buildTV(show *tvShow, order dbs.Order) *types.TV {
 
 	//group episodes by series number
 	groupedEpisodes := make(map[int][]*builder.EpisodeBuilder)
+	seriesIDs := make(map[int]int)
 
 	for _, episode := range show.Series.Episodes {
-		seriesNum := episode.AiredSeason
+		seriesNum, number, ok := episodeNumber(episode, order)
+		if !ok {
+			continue
+		}
 
 		//build episode
 		eb := builder.NewEpisodeBuilder()
 		eb.
+			WithID(int(episode.ID)).
 			WithTitle(episode.EpisodeName).
-			WithNumber(episode.AiredEpisodeNumber)
+			WithNumber(number).
+			WithAbsoluteNumber(episode.AbsoluteNumber).
+			WithOverview(episode.Overview).
+			WithExternalIDs(types.ExternalIDs{IMDB: episode.ImdbID})
+
+		if date, err := time.Parse(apiDateFormat, episode.FirstAired); err == nil {
+			eb.WithAirDate(date)
+		}
 
 		if _, ok := groupedEpisodes[seriesNum]; !ok {
 			groupedEpisodes[seriesNum] = []*builder.EpisodeBuilder{}
 		}
 
 		groupedEpisodes[seriesNum] = append(groupedEpisodes[seriesNum], eb)
+
+		//series IDs are of the aired series, so aren't known in other orders
+		if order == dbs.AiredOrder || seriesNum == specialEpisodes {
+			seriesIDs[seriesNum] = int(episode.AiredSeasonID)
+		}
 	}
 
-	//Get show's number of series
-	seriesCount := len(groupedEpisodes)
-	if _, ok := groupedEpisodes[specialEpisodes]; ok {
-		seriesCount -= 1 //Ignore series with number 0 as reserved for special episodes
+	//Get show's number of series, counting the fetched series if not given
+	seriesCount, err := strconv.Atoi(show.Season)
+	if err != nil {
+		seriesCount = len(groupedEpisodes)
+		if _, ok := groupedEpisodes[specialEpisodes]; ok {
+			seriesCount -= 1 //Ignore series with number 0 as reserved for special episodes
+		}
+	}
+	if order == dbs.AbsoluteOrder {
+		seriesCount = 1
 	}
 
 	//start tv build
 	tvb := builder.NewTVBuilder()
 	tvb.
+		WithID(int(show.ID)).
 		WithTitle(show.SeriesName).
-		WithSeriesCount(seriesCount)
+		WithOverview(show.Overview).
+		WithSeriesCount(seriesCount).
+		WithSeriesNumbers(seriesNumbers(show, order)).
+		WithVoteCount(show.SiteRatingCount).
+		WithPoster(show.Poster).
+		WithFanart(show.Fanart).
+		WithExternalIDs(types.ExternalIDs{IMDB: show.ImdbID})
+
+	//first aired is optional for shows yet to air
+	if date, err := time.Parse(apiDateFormat, show.FirstAired); err == nil {
+		tvb.WithReleaseDate(date)
+	}
 
 	//build series based on grouped episodes
 	for seriesNum, episodes := range groupedEpisodes {
@@ -254,6 +509,7 @@ func buildTV(show *tvShow) *types.TV {
 		//build series
 		sb := builder.NewSeriesBuilder()
 		sb.
+			WithID(seriesIDs[seriesNum]).
 			WithNumber(seriesNum).
 			WithTitle(seasonName)
 
@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/kylelemons/godebug/pretty"
 	"github.com/rustedturnip/media-mapper/types"
@@ -15,32 +16,20 @@ func TestTVDB_SearchTV(t *testing.T) {
 	var tests = []struct {
 		name       string
 		titleInput string
+		yearInput  int
 		expected   []*types.TV
 		responses  map[string]*http.Response //map[expectedURL]response
 	}{
 		{
-			name:       "Normal TV Search - 1 Season, Multi-Episodes (1 Page)",
+			name:       "Normal TV Search - Series Not Fetched",
 			titleInput: "Taboo",
 			expected: []*types.TV{
 				{
+					ID:          292157,
 					Title:       "Taboo (2017)",
-					SeriesCount: 1,
-					Series: map[int]*types.Series{
-						1: {
-							Title:  "Season 1",
-							Number: 1,
-							Episodes: map[int]*types.Episode{
-								1: {
-									Title:  "Episode 1",
-									Number: 1,
-								},
-								2: {
-									Title:  "Episode 2",
-									Number: 2,
-								},
-							},
-						},
-					},
+					Overview:    "James Keziah Delaney has been to the ends of the earth and comes back irrevocably changed. Believed to be long dead, he returns home to London from Africa to inherit what is left of his father's shipping empire and rebuild a life for himself. But his father's legacy is a poisoned chalice, and with enemies lurking in every dark corner, James must navigate increasingly complex territories to avoid his own death sentence. Encircled by conspiracy, murder and betrayal, a dark family mystery unfolds in a combustible tale of love and treachery.",
+					ReleaseDate: time.Unix(1483747200, 0).UTC(), //2017-01-07
+					Series:      map[int]*types.Series{},
 				},
 			},
 			responses: map[string]*http.Response{
@@ -67,6 +56,175 @@ func TestTVDB_SearchTV(t *testing.T) {
     ]
 }`)),
 				},
+			},
+		},
+		{
+			name:       "Normal TV Search - Alternative Script Aliases",
+			titleInput: "simpsons",
+			expected: []*types.TV{
+				{
+					ID:          71663,
+					Title:       "The Simpsons",
+					Overview:    "Set in Springfield, the average American town, the show focuses on the antics and everyday adventures of the Simpson family; Homer, Marge, Bart, Lisa and Maggie, as well as a virtual cast of thousands. Since the beginning, the series has been a pop culture icon, attracting hundreds of celebrities to guest star. The show has also made name for itself in its fearless satirical take on politics, media and American life in general.",
+					ReleaseDate: time.Unix(545788800, 0).UTC(), //1987-04-19
+					Series:      map[int]*types.Series{},
+				},
+			},
+			responses: map[string]*http.Response{
+				//search response
+				"https://api.thetvdb.com/search/series?name=simpsons": {
+					StatusCode: http.StatusOK,
+					Body: ioutil.NopCloser(bytes.NewBufferString(`{
+    "data": [
+        {
+            "aliases": [
+                "심슨"
+            ],
+            "banner": "/banners/graphical/71663-g13.jpg",
+            "firstAired": "1987-4-19",
+            "id": 71663,
+            "image": "/banners/posters/71663-15.jpg",
+            "network": "FOX",
+            "overview": "Set in Springfield, the average American town, the show focuses on the antics and everyday adventures of the Simpson family; Homer, Marge, Bart, Lisa and Maggie, as well as a virtual cast of thousands. Since the beginning, the series has been a pop culture icon, attracting hundreds of celebrities to guest star. The show has also made name for itself in its fearless satirical take on politics, media and American life in general.",
+            "poster": "/banners/posters/71663-15.jpg",
+            "seriesName": "The Simpsons",
+            "slug": "the-simpsons",
+            "status": "Continuing"
+        }
+    ]
+}`)),
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		//initialise db with test specific mock client with test's responses
+		db := TVDB{
+			httpClient: dbs.NewHttpClient(test.responses),
+		}
+
+		//test
+		result := db.SearchTV(test.titleInput, test.yearInput)
+		if diff := pretty.Compare(test.expected, result); diff != "" {
+			t.Errorf("%s unexpected diff (-want +got):\n%s", test.name, diff)
+		}
+	}
+}
+
+func TestTVDB_FindTV(t *testing.T) {
+	var tests = []struct {
+		name      string
+		idsInput  types.ExternalIDs
+		expected  []*types.TV
+		responses map[string]*http.Response //map[expectedURL]response
+	}{
+		{
+			name:     "Found by IMDb ID",
+			idsInput: types.ExternalIDs{IMDB: "tt3647998", TMDB: 65942},
+			expected: []*types.TV{
+				{
+					ID:          292157,
+					Title:       "Taboo (2017)",
+					ReleaseDate: time.Unix(1483747200, 0).UTC(), //2017-01-07
+					Series:      map[int]*types.Series{},
+				},
+			},
+			responses: map[string]*http.Response{
+				"https://api.thetvdb.com/search/series?imdbId=tt3647998": {
+					StatusCode: http.StatusOK,
+					Body: ioutil.NopCloser(bytes.NewBufferString(`{
+    "data": [
+        {
+            "aliases": [],
+            "banner": "/banners/graphical/292157-g3.jpg",
+            "firstAired": "2017-1-7",
+            "id": 292157,
+            "network": "BBC One",
+            "seriesName": "Taboo (2017)",
+            "slug": "taboo-2017",
+            "status": "Continuing"
+        }
+    ]
+}`)),
+				},
+			},
+		},
+		{
+			name:      "TMDB ID Only - Not Searched",
+			idsInput:  types.ExternalIDs{TMDB: 65942},
+			expected:  []*types.TV{},
+			responses: map[string]*http.Response{},
+		},
+	}
+
+	for _, test := range tests {
+		//initialise db with test specific mock client with test's responses
+		db := TVDB{
+			httpClient: dbs.NewHttpClient(test.responses),
+		}
+
+		//test
+		result := db.FindTV(test.idsInput)
+		if diff := pretty.Compare(test.expected, result); diff != "" {
+			t.Errorf("%s unexpected diff (-want +got):\n%s", test.name, diff)
+		}
+	}
+}
+
+func TestTVDB_GetTV(t *testing.T) {
+	var tests = []struct {
+		name        string
+		idInput     int
+		orderInput  dbs.Order
+		seriesInput []int
+		expected    *types.TV
+		responses   map[string]*http.Response //map[expectedURL]response
+	}{
+		{
+			name:        "Single Series Show",
+			idInput:     292157,
+			seriesInput: []int{1},
+			expected: &types.TV{
+				ID:            292157,
+				Title:         "Taboo (2017)",
+				Overview:      "James Keziah Delaney has been to the ends of the earth and comes back irrevocably changed. Believed to be long dead, he returns home to London from Africa to inherit what is left of his father's shipping empire and rebuild a life for himself. But his father's legacy is a poisoned chalice, and with enemies lurking in every dark corner, James must navigate increasingly complex territories to avoid his own death sentence. Encircled by conspiracy, murder and betrayal, a dark family mystery unfolds in a combustible tale of love and treachery.",
+				SeriesCount:   1,
+				SeriesNumbers: []int{1},
+				ReleaseDate:   time.Unix(1483747200, 0).UTC(), //2017-01-07
+				VoteCount:     887,
+				Poster:        "posters/292157-1.jpg",
+				Fanart:        "fanart/original/292157-2.jpg",
+				ExternalIDs:   types.ExternalIDs{IMDB: "tt3647998"},
+				Series: map[int]*types.Series{
+					1: {
+						ID:     682219,
+						Title:  "Season 1",
+						Number: 1,
+						Episodes: map[int]*types.Episode{
+							1: {
+								ID:             5840491,
+								Title:          "Episode 1",
+								Number:         1,
+								AbsoluteNumber: 1,
+								Overview:       "In the series opener, James Delaney returns to 1814 London after ten years in Africa to claim a mysterious legacy left to him by his father.",
+								AirDate:        time.Unix(1483747200, 0).UTC(), //2017-01-07
+								ExternalIDs:    types.ExternalIDs{IMDB: "tt4700596"},
+							},
+							2: {
+								ID:             5840501,
+								Title:          "Episode 2",
+								Number:         2,
+								AbsoluteNumber: 2,
+								Overview:       "As James Delaney assembles his league of the damned; an unexpected arrival threatens to disrupt his plans. ",
+								AirDate:        time.Unix(1484352000, 0).UTC(), //2017-01-14
+								ExternalIDs:    types.ExternalIDs{IMDB: "tt4700604"},
+							},
+						},
+					},
+				},
+			},
+			responses: map[string]*http.Response{
 				//seriesByID response
 				"https://api.thetvdb.com/series/292157": {
 					StatusCode: http.StatusOK,
@@ -106,8 +264,19 @@ func TestTVDB_SearchTV(t *testing.T) {
 }`)),
 				},
 
+				//Episodes Summary response
+				"https://api.thetvdb.com/series/292157/episodes/summary": {
+					StatusCode: http.StatusOK,
+					Body: ioutil.NopCloser(bytes.NewBufferString(`{
+    "data": {
+        "airedSeasons": ["0", "1"],
+        "dvdSeasons": ["1"]
+    }
+}`)),
+				},
+
 				//Episodes By Series ID response
-				"https://api.thetvdb.com/series/292157/episodes?page=1": {
+				"https://api.thetvdb.com/series/292157/episodes/query?airedSeason=1&page=1": {
 					StatusCode: http.StatusOK,
 					Body: ioutil.NopCloser(bytes.NewBufferString(`{
     "links": {
@@ -209,82 +378,99 @@ func TestTVDB_SearchTV(t *testing.T) {
 			},
 		},
 		{
-			name:       "Normal TV Search - Multi-Season, Multi-Episodes (Multiple Pages)",
-			titleInput: "simpsons",
-			expected: []*types.TV{
-				{
-					Title:       "The Simpsons",
-					SeriesCount: 3,
-					Series: map[int]*types.Series{
-						1: {
-							Title:  "Season 1",
-							Number: 1,
-							Episodes: map[int]*types.Episode{
-								1: {
-									Title:  "Simpsons Roasting on an Open Fire",
-									Number: 1,
-								},
-								2: {
-									Title:  "Bart the Genius",
-									Number: 2,
-								},
+			name:        "Multi-Series Show",
+			idInput:     71663,
+			seriesInput: []int{1, 2, 3},
+			expected: &types.TV{
+				ID:            71663,
+				Title:         "The Simpsons",
+				Overview:      "Set in Springfield, the average American town, the show focuses on the antics and everyday adventures of the Simpson family; Homer, Marge, Bart, Lisa and Maggie, as well as a virtual cast of thousands. Since the beginning, the series has been a pop culture icon, attracting hundreds of celebrities to guest star. The show has also made name for itself in its fearless satirical take on politics, media and American life in general.",
+				SeriesCount:   32,
+				SeriesNumbers: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32},
+				ReleaseDate:   time.Unix(545788800, 0).UTC(), //1987-04-19
+				VoteCount:     24136,
+				Poster:        "posters/71663-15.jpg",
+				Fanart:        "fanart/original/71663-10.jpg",
+				ExternalIDs:   types.ExternalIDs{IMDB: "tt0096697"},
+				Series: map[int]*types.Series{
+					1: {
+						ID:     2727,
+						Title:  "Season 1",
+						Number: 1,
+						Episodes: map[int]*types.Episode{
+							1: {
+								ID:             55452,
+								Title:          "Simpsons Roasting on an Open Fire",
+								Number:         1,
+								AbsoluteNumber: 1,
+								Overview:       "When his Christmas bonus is cancelled, Homer becomes a department-store Santa--and then bets his meager earnings at the track. When all seems lost, Homer and Bart save Christmas by adopting the losing greyhound, Santa's Little Helper.",
+								AirDate:        time.Unix(629856000, 0).UTC(), //1989-12-17
+								ExternalIDs:    types.ExternalIDs{IMDB: "tt0348034"},
+							},
+							2: {
+								ID:             55453,
+								Title:          "Bart the Genius",
+								Number:         2,
+								AbsoluteNumber: 2,
+								Overview:       "After switching IQ tests with Martin, Bart is mistaken for a child genius. When he's enrolled in a school for gifted students, a series of embarrassments and mishaps makes him long for his old life.",
+								AirDate:        time.Unix(632275200, 0).UTC(), //1990-01-14
+								ExternalIDs:    types.ExternalIDs{IMDB: "tt0756593"},
 							},
 						},
-						2: {
-							Title:  "Season 2",
-							Number: 2,
-							Episodes: map[int]*types.Episode{
-								1: {
-									Title:  "Bart Gets an \"F\"",
-									Number: 1,
-								},
-								2: {
-									Title:  "Simpson and Delilah",
-									Number: 2,
-								},
+					},
+					2: {
+						ID:     2728,
+						Title:  "Season 2",
+						Number: 2,
+						Episodes: map[int]*types.Episode{
+							1: {
+								ID:             55452,
+								Title:          "Bart Gets an \"F\"",
+								Number:         1,
+								AbsoluteNumber: 1,
+								Overview:       "When his Christmas bonus is cancelled, Homer becomes a department-store Santa--and then bets his meager earnings at the track. When all seems lost, Homer and Bart save Christmas by adopting the losing greyhound, Santa's Little Helper.",
+								AirDate:        time.Unix(629856000, 0).UTC(), //1989-12-17
+								ExternalIDs:    types.ExternalIDs{IMDB: "tt0348034"},
+							},
+							2: {
+								ID:             55453,
+								Title:          "Simpson and Delilah",
+								Number:         2,
+								AbsoluteNumber: 2,
+								Overview:       "After switching IQ tests with Martin, Bart is mistaken for a child genius. When he's enrolled in a school for gifted students, a series of embarrassments and mishaps makes him long for his old life.",
+								AirDate:        time.Unix(632275200, 0).UTC(), //1990-01-14
+								ExternalIDs:    types.ExternalIDs{IMDB: "tt0756593"},
 							},
 						},
-						3: {
-							Title:  "Season 3",
-							Number: 3,
-							Episodes: map[int]*types.Episode{
-								1: {
-									Title:  "Stark Raving Dad",
-									Number: 1,
-								},
-								2: {
-									Title:  "Mr. Lisa Goes to Washington",
-									Number: 2,
-								},
+					},
+					3: {
+						ID:     2729,
+						Title:  "Season 3",
+						Number: 3,
+						Episodes: map[int]*types.Episode{
+							1: {
+								ID:             55452,
+								Title:          "Stark Raving Dad",
+								Number:         1,
+								AbsoluteNumber: 1,
+								Overview:       "When his Christmas bonus is cancelled, Homer becomes a department-store Santa--and then bets his meager earnings at the track. When all seems lost, Homer and Bart save Christmas by adopting the losing greyhound, Santa's Little Helper.",
+								AirDate:        time.Unix(629856000, 0).UTC(), //1989-12-17
+								ExternalIDs:    types.ExternalIDs{IMDB: "tt0348034"},
+							},
+							2: {
+								ID:             55453,
+								Title:          "Mr. Lisa Goes to Washington",
+								Number:         2,
+								AbsoluteNumber: 2,
+								Overview:       "After switching IQ tests with Martin, Bart is mistaken for a child genius. When he's enrolled in a school for gifted students, a series of embarrassments and mishaps makes him long for his old life.",
+								AirDate:        time.Unix(632275200, 0).UTC(), //1990-01-14
+								ExternalIDs:    types.ExternalIDs{IMDB: "tt0756593"},
 							},
 						},
 					},
 				},
 			},
 			responses: map[string]*http.Response{
-				//search response
-				"https://api.thetvdb.com/search/series?name=simpsons": {
-					StatusCode: http.StatusOK,
-					Body: ioutil.NopCloser(bytes.NewBufferString(`{
-    "data": [
-        {
-            "aliases": [
-                "심슨"
-            ],
-            "banner": "/banners/graphical/71663-g13.jpg",
-            "firstAired": "1987-4-19",
-            "id": 71663,
-            "image": "/banners/posters/71663-15.jpg",
-            "network": "FOX",
-            "overview": "Set in Springfield, the average American town, the show focuses on the antics and everyday adventures of the Simpson family; Homer, Marge, Bart, Lisa and Maggie, as well as a virtual cast of thousands. Since the beginning, the series has been a pop culture icon, attracting hundreds of celebrities to guest star. The show has also made name for itself in its fearless satirical take on politics, media and American life in general.",
-            "poster": "/banners/posters/71663-15.jpg",
-            "seriesName": "The Simpsons",
-            "slug": "the-simpsons",
-            "status": "Continuing"
-        }
-    ]
-}`)),
-				},
 				//seriesByID response
 				"https://api.thetvdb.com/series/71663": {
 					StatusCode: http.StatusOK,
@@ -324,14 +510,25 @@ func TestTVDB_SearchTV(t *testing.T) {
 }`)),
 				},
 
+				//Episodes Summary response
+				"https://api.thetvdb.com/series/71663/episodes/summary": {
+					StatusCode: http.StatusOK,
+					Body: ioutil.NopCloser(bytes.NewBufferString(`{
+    "data": {
+        "airedSeasons": ["0", "1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16", "17", "18", "19", "20", "21", "22", "23", "24", "25", "26", "27", "28", "29", "30", "31", "32"],
+        "dvdSeasons": ["0", "1", "2", "3"]
+    }
+}`)),
+				},
+
 				//Episodes By Series ID response - page 1
-				"https://api.thetvdb.com/series/71663/episodes?page=1": {
+				"https://api.thetvdb.com/series/71663/episodes/query?airedSeason=1&page=1": {
 					StatusCode: http.StatusOK,
 					Body: ioutil.NopCloser(bytes.NewBufferString(`{
   "links": {
     "first": 1,
-    "last": 3,
-    "next": 2,
+    "last": 1,
+    "next": null,
     "prev": null
   },
   "data": [
@@ -429,14 +626,14 @@ func TestTVDB_SearchTV(t *testing.T) {
 }`)),
 				},
 				//Episodes By Series ID response - page 2
-				"https://api.thetvdb.com/series/71663/episodes?page=2": {
+				"https://api.thetvdb.com/series/71663/episodes/query?airedSeason=2&page=1": {
 					StatusCode: http.StatusOK,
 					Body: ioutil.NopCloser(bytes.NewBufferString(`{
   "links": {
     "first": 1,
-    "last": 3,
-    "next": 3,
-    "prev": 1
+    "last": 1,
+    "next": null,
+    "prev": null
   },
   "data": [
     {
@@ -533,14 +730,14 @@ func TestTVDB_SearchTV(t *testing.T) {
 }`)),
 				},
 				//Episodes By Series ID response - page 3
-				"https://api.thetvdb.com/series/71663/episodes?page=3": {
+				"https://api.thetvdb.com/series/71663/episodes/query?airedSeason=3&page=1": {
 					StatusCode: http.StatusOK,
 					Body: ioutil.NopCloser(bytes.NewBufferString(`{
   "links": {
     "first": 1,
-    "last": 3,
+    "last": 1,
     "next": null,
-    "prev": 2
+    "prev": null
   },
   "data": [
     {
@@ -634,6 +831,291 @@ func TestTVDB_SearchTV(t *testing.T) {
 		"isMovie": 0
 	}
   ]
+}`)),
+				},
+			},
+		},
+		{
+			name:        "DVD Order",
+			idInput:     78874,
+			orderInput:  dbs.DVDOrder,
+			seriesInput: []int{1},
+			expected: &types.TV{
+				ID:            78874,
+				Title:         "Firefly",
+				Overview:      "Five hundred years in the future, a renegade crew aboard a small spacecraft tries to survive as they travel the unknown parts of the galaxy and evade warring factions as well as authority agents out to get them.",
+				SeriesCount:   1,
+				SeriesNumbers: []int{1},
+				ReleaseDate:   time.Unix(1032480000, 0).UTC(), //2002-09-20
+				VoteCount:     1163,
+				Poster:        "posters/78874-1.jpg",
+				Fanart:        "fanart/original/78874-1.jpg",
+				ExternalIDs:   types.ExternalIDs{IMDB: "tt0303461"},
+				Series: map[int]*types.Series{
+					1: {
+						Title:  "Season 1",
+						Number: 1,
+						Episodes: map[int]*types.Episode{
+							1: {
+								ID:             297999,
+								Title:          "Serenity",
+								Number:         1,
+								AbsoluteNumber: 1,
+								AirDate:        time.Unix(1040342400, 0).UTC(), //2002-12-20
+							},
+							2: {
+								ID:             297989,
+								Title:          "The Train Job",
+								Number:         2,
+								AbsoluteNumber: 2,
+								AirDate:        time.Unix(1032480000, 0).UTC(), //2002-09-20
+							},
+						},
+					},
+				},
+			},
+			responses: map[string]*http.Response{
+				"https://api.thetvdb.com/series/78874": {
+					StatusCode: http.StatusOK,
+					Body: ioutil.NopCloser(bytes.NewBufferString(`{
+    "data": {
+        "id": 78874,
+        "seriesName": "Firefly",
+        "season": "1",
+        "poster": "posters/78874-1.jpg",
+        "fanart": "fanart/original/78874-1.jpg",
+        "firstAired": "2002-09-20",
+        "overview": "Five hundred years in the future, a renegade crew aboard a small spacecraft tries to survive as they travel the unknown parts of the galaxy and evade warring factions as well as authority agents out to get them.",
+        "imdbId": "tt0303461",
+        "siteRatingCount": 1163
+    }
+}`)),
+				},
+
+				//Episodes Summary response
+				"https://api.thetvdb.com/series/78874/episodes/summary": {
+					StatusCode: http.StatusOK,
+					Body: ioutil.NopCloser(bytes.NewBufferString(`{
+    "data": {
+        "airedSeasons": ["0", "1"],
+        "dvdSeasons": ["0", "1"]
+    }
+}`)),
+				},
+
+				//episodes are queried by their DVD series
+				"https://api.thetvdb.com/series/78874/episodes/query?dvdSeason=1&page=1": {
+					StatusCode: http.StatusOK,
+					Body: ioutil.NopCloser(bytes.NewBufferString(`{
+    "links": {
+        "first": 1,
+        "last": 1,
+        "next": null,
+        "prev": null
+    },
+    "data": [
+        {
+            "id": 297989,
+            "airedSeason": 1,
+            "airedSeasonID": 30110,
+            "airedEpisodeNumber": 1,
+            "episodeName": "The Train Job",
+            "firstAired": "2002-09-20",
+            "dvdSeason": 1,
+            "dvdEpisodeNumber": 2,
+            "absoluteNumber": 2
+        },
+        {
+            "id": 297999,
+            "airedSeason": 1,
+            "airedSeasonID": 30110,
+            "airedEpisodeNumber": 11,
+            "episodeName": "Serenity",
+            "firstAired": "2002-12-20",
+            "dvdSeason": 1,
+            "dvdEpisodeNumber": 1,
+            "absoluteNumber": 1
+        }
+    ]
+}`)),
+				},
+			},
+		},
+		{
+			name:        "Seasons Numbered by Year",
+			idInput:     71256,
+			seriesInput: []int{2020},
+			expected: &types.TV{
+				ID:            71256,
+				Title:         "The Daily Show",
+				SeriesCount:   2,
+				SeriesNumbers: []int{2019, 2020},
+				ReleaseDate:   time.Unix(837993600, 0).UTC(), //1996-07-22
+				Series: map[int]*types.Series{
+					2020: {
+						ID:     812345,
+						Title:  "Season 2020",
+						Number: 2020,
+						Episodes: map[int]*types.Episode{
+							1: {
+								ID:      7560001,
+								Title:   "January 6, 2020",
+								Number:  1,
+								AirDate: time.Unix(1578268800, 0).UTC(), //2020-01-06
+							},
+						},
+					},
+				},
+			},
+			responses: map[string]*http.Response{
+				"https://api.thetvdb.com/series/71256": {
+					StatusCode: http.StatusOK,
+					Body: ioutil.NopCloser(bytes.NewBufferString(`{
+    "data": {
+        "id": 71256,
+        "seriesName": "The Daily Show",
+        "season": "2",
+        "firstAired": "1996-07-22"
+    }
+}`)),
+				},
+
+				//daily shows are numbered by the year they aired
+				"https://api.thetvdb.com/series/71256/episodes/summary": {
+					StatusCode: http.StatusOK,
+					Body: ioutil.NopCloser(bytes.NewBufferString(`{
+    "data": {
+        "airedSeasons": ["2020", "0", "2019"],
+        "dvdSeasons": []
+    }
+}`)),
+				},
+
+				"https://api.thetvdb.com/series/71256/episodes/query?airedSeason=2020&page=1": {
+					StatusCode: http.StatusOK,
+					Body: ioutil.NopCloser(bytes.NewBufferString(`{
+    "links": {
+        "first": 1,
+        "last": 1,
+        "next": null,
+        "prev": null
+    },
+    "data": [
+        {
+            "id": 7560001,
+            "airedSeason": 2020,
+            "airedSeasonID": 812345,
+            "airedEpisodeNumber": 1,
+            "episodeName": "January 6, 2020",
+            "firstAired": "2020-01-06"
+        }
+    ]
+}`)),
+				},
+			},
+		},
+		{
+			name:        "Absolute Order",
+			idInput:     78874,
+			orderInput:  dbs.AbsoluteOrder,
+			seriesInput: []int{1},
+			expected: &types.TV{
+				ID:            78874,
+				Title:         "Firefly",
+				Overview:      "Five hundred years in the future, a renegade crew aboard a small spacecraft tries to survive as they travel the unknown parts of the galaxy and evade warring factions as well as authority agents out to get them.",
+				SeriesCount:   1,
+				SeriesNumbers: []int{1},
+				ReleaseDate:   time.Unix(1032480000, 0).UTC(), //2002-09-20
+				VoteCount:     1163,
+				Poster:        "posters/78874-1.jpg",
+				Fanart:        "fanart/original/78874-1.jpg",
+				ExternalIDs:   types.ExternalIDs{IMDB: "tt0303461"},
+				Series: map[int]*types.Series{
+					1: {
+						Title:  "Season 1",
+						Number: 1,
+						Episodes: map[int]*types.Episode{
+							1: {
+								ID:             297999,
+								Title:          "Serenity",
+								Number:         1,
+								AbsoluteNumber: 1,
+								AirDate:        time.Unix(1040342400, 0).UTC(), //2002-12-20
+							},
+							2: {
+								ID:             297989,
+								Title:          "The Train Job",
+								Number:         2,
+								AbsoluteNumber: 2,
+								AirDate:        time.Unix(1032480000, 0).UTC(), //2002-09-20
+							},
+						},
+					},
+				},
+			},
+			responses: map[string]*http.Response{
+				"https://api.thetvdb.com/series/78874": {
+					StatusCode: http.StatusOK,
+					Body: ioutil.NopCloser(bytes.NewBufferString(`{
+    "data": {
+        "id": 78874,
+        "seriesName": "Firefly",
+        "season": "1",
+        "poster": "posters/78874-1.jpg",
+        "fanart": "fanart/original/78874-1.jpg",
+        "firstAired": "2002-09-20",
+        "overview": "Five hundred years in the future, a renegade crew aboard a small spacecraft tries to survive as they travel the unknown parts of the galaxy and evade warring factions as well as authority agents out to get them.",
+        "imdbId": "tt0303461",
+        "siteRatingCount": 1163
+    }
+}`)),
+				},
+
+				//every episode is fetched, without specials as they aren't numbered absolutely
+				"https://api.thetvdb.com/series/78874/episodes?page=1": {
+					StatusCode: http.StatusOK,
+					Body: ioutil.NopCloser(bytes.NewBufferString(`{
+    "links": {
+        "first": 1,
+        "last": 1,
+        "next": null,
+        "prev": null
+    },
+    "data": [
+        {
+            "id": 297989,
+            "airedSeason": 1,
+            "airedSeasonID": 30110,
+            "airedEpisodeNumber": 1,
+            "episodeName": "The Train Job",
+            "firstAired": "2002-09-20",
+            "dvdSeason": 1,
+            "dvdEpisodeNumber": 2,
+            "absoluteNumber": 2
+        },
+        {
+            "id": 297999,
+            "airedSeason": 1,
+            "airedSeasonID": 30110,
+            "airedEpisodeNumber": 11,
+            "episodeName": "Serenity",
+            "firstAired": "2002-12-20",
+            "dvdSeason": 1,
+            "dvdEpisodeNumber": 1,
+            "absoluteNumber": 1
+        },
+        {
+            "id": 306446,
+            "airedSeason": 0,
+            "airedSeasonID": 30111,
+            "airedEpisodeNumber": 1,
+            "episodeName": "Here's How It Was: The Making of Firefly",
+            "firstAired": "2003-12-09",
+            "dvdSeason": null,
+            "dvdEpisodeNumber": null,
+            "absoluteNumber": null
+        }
+    ]
 }`)),
 				},
 			},
@@ -647,7 +1129,47 @@ func TestTVDB_SearchTV(t *testing.T) {
 		}
 
 		//test
-		result := db.SearchTV(test.titleInput)
+		result := db.GetTV(test.idInput, test.orderInput, test.seriesInput...)
+		if diff := pretty.Compare(test.expected, result); diff != "" {
+			t.Errorf("%s unexpected diff (-want +got):\n%s", test.name, diff)
+		}
+	}
+}
+
+func TestTVDB_filterByYear(t *testing.T) {
+
+	doctorWho1963 := &tvSearchResult{ID: 76107, SeriesName: "Doctor Who", FirstAired: "1963-11-23"}
+	doctorWho2005 := &tvSearchResult{ID: 78804, SeriesName: "Doctor Who (2005)", FirstAired: "2005-03-26"}
+	unaired := &tvSearchResult{ID: 1, SeriesName: "Doctor Who"}
+
+	var tests = []struct {
+		name      string
+		input     []*tvSearchResult
+		yearInput int
+		expected  []*tvSearchResult
+	}{
+		{
+			name:      "Year Matches One Result",
+			input:     []*tvSearchResult{doctorWho1963, doctorWho2005, unaired},
+			yearInput: 2005,
+			expected:  []*tvSearchResult{doctorWho2005},
+		},
+		{
+			name:      "Year Matches No Results",
+			input:     []*tvSearchResult{doctorWho1963, doctorWho2005, unaired},
+			yearInput: 2010,
+			expected:  []*tvSearchResult{doctorWho1963, doctorWho2005, unaired},
+		},
+		{
+			name:      "Unknown Year",
+			input:     []*tvSearchResult{doctorWho1963, doctorWho2005},
+			yearInput: 0,
+			expected:  []*tvSearchResult{doctorWho1963, doctorWho2005},
+		},
+	}
+
+	for _, test := range tests {
+		result := filterByYear(test.input, test.yearInput)
 		if diff := pretty.Compare(test.expected, result); diff != "" {
 			t.Errorf("%s unexpected diff (-want +got):\n%s", test.name, diff)
 		}
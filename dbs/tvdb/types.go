@@ -39,6 +39,7 @@ type tvShow struct {
 	AirsTime        string   `json:"airsTime"`
 	Aliases         []string `json:"aliases"`
 	Banner          string   `json:"banner"`
+	Fanart          string   `json:"fanart"`
 	FirstAired      string   `json:"firstAired"`
 	Genre           []string `json:"genre"`
 	ID              uint64   `json:"id"`
@@ -47,8 +48,10 @@ type tvShow struct {
 	Network         string   `json:"network"`
 	NetworkID       string   `json:"networkId"`
 	Overview        string   `json:"overview"`
+	Poster          string   `json:"poster"`
 	Rating          string   `json:"rating"`
 	Runtime         string   `json:"runtime"`
+	Season          string   `json:"season"` //number of series
 	SeriesID        string   `json:"seriesId"`
 	SeriesName      string   `json:"seriesName"`
 	SiteRating      float64  `json:"siteRating"`
@@ -57,6 +60,16 @@ type tvShow struct {
 	Status          string   `json:"status"`
 	Zap2ItID        string   `json:"zap2itId"`
 	Series          *tvSeriesEpisodes
+	Summary         *episodesSummary
+}
+
+type episodesSummaryResponse struct {
+	Summary *episodesSummary `json:"data"`
+}
+
+type episodesSummary struct {
+	AiredSeasons []string `json:"airedSeasons"`
+	DVDSeasons   []string `json:"dvdSeasons"`
 }
 
 type tvSeriesEpisodes struct {
@@ -69,6 +82,7 @@ type episode struct {
 	AbsoluteNumber     int      `json:"absoluteNumber"`
 	AiredEpisodeNumber int      `json:"airedEpisodeNumber"`
 	AiredSeason        int      `json:"airedSeason"`
+	AiredSeasonID      uint64   `json:"airedSeasonID"`
 	AirsAfterSeason    int      `json:"airsAfterSeason"`
 	AirsBeforeEpisode  int      `json:"airsBeforeEpisode"`
 	AirsBeforeSeason   int      `json:"airsBeforeSeason"`
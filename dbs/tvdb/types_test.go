@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/kylelemons/godebug/pretty"
+	"github.com/rustedturnip/media-mapper/dbs"
 	"github.com/rustedturnip/media-mapper/types"
 )
 
@@ -170,7 +171,7 @@ func TestTVDB_buildMovie(t *testing.T) {
 
 	//run tests
 	for _, test := range tests {
-		result := buildTV(test.input)
+		result := buildTV(test.input, dbs.AiredOrder)
 		if diff := pretty.Compare(test.expected, result); diff != "" {
 			t.Errorf("%s unexpected diff (-want +got):\n%s", test.name, diff)
 		}
@@ -0,0 +1,125 @@
+package filing
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+//Action is what's done with a companion when its media file is renamed
+type Action string
+
+const (
+	ActionRename Action = "rename" //renamed after the media file and moved with it
+	ActionMove   Action = "move"   //moved with the media file, keeping its name
+	ActionDelete Action = "delete"
+	ActionLeave  Action = "leave"
+)
+
+var (
+	//Actions are the companion actions by name
+	Actions = map[string]Action{
+		string(ActionRename): ActionRename,
+		string(ActionMove):   ActionMove,
+		string(ActionDelete): ActionDelete,
+		string(ActionLeave):  ActionLeave,
+	}
+)
+
+//returns the media file the companion at path belongs to, if any, along with
+//the part of its name following the media file's name. A companion belongs
+//to a media file if it's named after it, or is the only media file beside
+//the companion, or beside the folder (one of dirs) the companion is in.
+func (f *Filer) companionOf(path string, dirs map[string]struct{}) (*File, string) {
+
+	dir := filepath.Dir(path)
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+
+	//beside the media file
+	if file, rest := named(f.files[dir], name); file != nil {
+		return file, rest
+	}
+	if len(f.files[dir]) == 1 {
+		return f.files[dir][0], name
+	}
+
+	//in a folder beside the media file, e.g. Subs/English.srt
+	if isIn(dir, dirs) {
+		parent := filepath.Dir(dir)
+		if file, rest := named(f.files[parent], name); file != nil {
+			return file, rest
+		}
+		if len(f.files[parent]) == 1 {
+			return f.files[parent][0], name
+		}
+		return nil, ""
+	}
+
+	//in a folder named after the media file, as in season packs, e.g.
+	//Subs/Show.S01E01/2_English.srt
+	if parent := filepath.Dir(dir); isIn(parent, dirs) {
+		for _, file := range f.files[filepath.Dir(parent)] {
+			if strings.EqualFold(file.Name, filepath.Base(dir)) {
+				return file, name
+			}
+		}
+	}
+
+	return nil, ""
+}
+
+//returns the file whose name the companion name starts with, the longest if
+//several do, and the rest of the companion name
+func named(files []*File, name string) (*File, string) {
+
+	var match *File
+	for _, file := range files {
+		if len(name) < len(file.Name) || !strings.EqualFold(name[:len(file.Name)], file.Name) {
+			continue
+		}
+		if len(name) > len(file.Name) && !strings.ContainsRune("._- ", rune(name[len(file.Name)])) {
+			continue
+		}
+		if match == nil || len(file.Name) > len(match.Name) {
+			match = file
+		}
+	}
+
+	if match == nil {
+		return nil, ""
+	}
+
+	return match, name[len(match.Name):]
+}
+
+func isIn(dir string, dirs map[string]struct{}) bool {
+	_, ok := dirs[strings.ToLower(filepath.Base(dir))]
+	return ok
+}
+
+//numbers the renamed companions of each file that would otherwise share a
+//name, e.g. two English subtitles
+func (f *Filer) numberCompanions() {
+
+	for _, files := range f.files {
+		for _, file := range files {
+			taken := map[string]bool{
+				strings.ToLower(file.Ext): true,
+			}
+
+			for _, companion := range file.Companions {
+				if companion.Action != ActionRename {
+					continue
+				}
+
+				suffix := companion.Suffix
+				for n := 2; taken[strings.ToLower(suffix+companion.Ext)]; n++ {
+					suffix = fmt.Sprintf(".%d%s", n, companion.Suffix)
+				}
+
+				companion.Suffix = suffix
+				taken[strings.ToLower(suffix+companion.Ext)] = true
+			}
+		}
+	}
+}
@@ -0,0 +1,320 @@
+package filing
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+//Strategy is how a conflict between renames is resolved
+type Strategy string
+
+const (
+	StrategySkip    Strategy = "skip"    //leave all conflicting files unchanged
+	StrategySuffix  Strategy = "suffix"  //number the names of all but one of the files, e.g. "Name (2)"
+	StrategyQuality Strategy = "quality" //rename only the highest quality file, replacing an existing one of lower quality
+	StrategyReplace Strategy = "replace" //rename the first file, replacing an existing one
+)
+
+var Strategies = map[string]Strategy{
+	string(StrategySkip):    StrategySkip,
+	string(StrategySuffix):  StrategySuffix,
+	string(StrategyQuality): StrategyQuality,
+	string(StrategyReplace): StrategyReplace,
+}
+
+//Conflict is a new path that several files would be renamed to, or that
+//already exists
+type Conflict struct {
+	Path   string
+	Exists bool //Path is an existing file that isn't being renamed
+
+	renames []*rename
+}
+
+//a file and the directory it's in, or one of its companions
+type rename struct {
+	dir       string
+	file      *File
+	companion *Companion //nil if the file itself is renamed
+}
+
+func (r *rename) path() string {
+	return filepath.Join(r.dir, r.file.GetName())
+}
+
+//returns the path of the file or companion being renamed
+func (r *rename) source() string {
+
+	if r.companion != nil {
+		return r.companion.Path
+	}
+
+	return r.path()
+}
+
+//QualityFunc scores the quality of the media file at path, higher is better,
+//or 0 if its quality isn't known, e.g. a file already named by the filer
+type QualityFunc func(path string) int
+
+//FindConflicts returns the new paths that would be overwritten by the batch
+//rename, as they're either shared by several files (or their companions) or
+//already exist. Files being replaced (with Overwrite set) are not in conflict.
+func (f *Filer) FindConflicts() []*Conflict {
+
+	targets := make(map[string]*Conflict)
+	var paths []string
+
+	add := func(path string, r *rename) {
+		if _, ok := targets[path]; !ok {
+			targets[path] = &Conflict{Path: path}
+			paths = append(paths, path)
+		}
+		targets[path].renames = append(targets[path].renames, r)
+	}
+
+	for _, dir := range f.GetDirs() {
+		for _, file := range f.files[dir] {
+			if file.NewName == "" {
+				continue
+			}
+
+			add(file.GetNewPath(dir), &rename{dir: dir, file: file})
+
+			//companions of files with different names may still share one,
+			//e.g. the subtitles of Show - 1x1.mkv and Show - 1x1.mp4
+			for _, companion := range file.Companions {
+				if companion.Action == ActionDelete {
+					continue
+				}
+
+				path := companion.GetNewPath(dir, file)
+				if filepath.Clean(path) != filepath.Clean(companion.Path) {
+					add(path, &rename{dir: dir, file: file, companion: companion})
+				}
+			}
+		}
+	}
+
+	var conflicts []*Conflict
+	for _, path := range paths {
+		conflict := targets[path]
+
+		conflict.Exists = existing(conflict)
+		if conflict.Exists || len(conflict.renames) > 1 {
+			conflicts = append(conflicts, conflict)
+		}
+	}
+
+	return conflicts
+}
+
+//returns true if the conflict's path is an existing file other than the
+//file(s) being renamed to it, e.g. a case only rename, or one being replaced
+func existing(conflict *Conflict) bool {
+
+	if _, err := os.Stat(conflict.Path); err != nil {
+		return false
+	}
+
+	for _, r := range conflict.renames {
+		if (r.companion == nil && r.file.Overwrite) || inPlace(r, conflict.Path) {
+			return false
+		}
+	}
+
+	return true
+}
+
+//returns true if the file (or companion) of r is already at path
+func inPlace(r *rename, path string) bool {
+
+	source, err := os.Stat(r.source())
+	if err != nil {
+		return false
+	}
+
+	target, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+
+	return os.SameFile(source, target)
+}
+
+//returns true if renaming r to path would replace another file
+func occupied(r *rename, path string) bool {
+
+	if _, err := os.Stat(path); err != nil {
+		return false
+	}
+
+	return !inPlace(r, path)
+}
+
+//ResolveConflicts changes the new names of the files in each conflict so
+//that no file is overwritten, other than those the strategy replaces. A
+//file already at the conflicting path counts as existing, so is only kept
+//by the skip and suffix strategies. Companions never replace a file, so
+//other than when skipping, those in conflict are left where they are.
+func (f *Filer) ResolveConflicts(conflicts []*Conflict, strategy Strategy, quality QualityFunc) {
+
+	for _, conflict := range conflicts {
+		if strategy != StrategySkip {
+			leaveCompanions(conflict)
+		}
+
+		renames := conflict.renames
+		if len(renames) == 0 {
+			continue
+		}
+
+		switch strategy {
+		case StrategySuffix:
+			//file already at the path keeps its name
+			sort.SliceStable(renames, func(i, j int) bool {
+				return inPlace(renames[i], conflict.Path) && !inPlace(renames[j], conflict.Path)
+			})
+			f.suffix(conflict)
+
+		case StrategyQuality:
+			sort.SliceStable(renames, func(i, j int) bool {
+				return better(renames[i].path(), renames[j].path(), quality)
+			})
+
+			best := renames[0]
+			skip(renames[1:])
+
+			if conflict.Exists && !better(best.path(), conflict.Path, quality) {
+				skip(renames[:1])
+				continue
+			}
+			best.file.Overwrite = occupied(best, conflict.Path)
+
+		case StrategyReplace:
+			//file already at the path is the one replaced
+			sort.SliceStable(renames, func(i, j int) bool {
+				return !inPlace(renames[i], conflict.Path) && inPlace(renames[j], conflict.Path)
+			})
+
+			skip(renames[1:])
+			renames[0].file.Overwrite = occupied(renames[0], conflict.Path)
+
+		default:
+			skip(renames)
+		}
+	}
+}
+
+//leaves the companions in conflict where they are, removing them from its
+//renames. A companion keeps the path if it's the only one renamed to it.
+func leaveCompanions(conflict *Conflict) {
+
+	var files, companions []*rename
+	for _, r := range conflict.renames {
+		if r.companion != nil {
+			companions = append(companions, r)
+		} else {
+			files = append(files, r)
+		}
+	}
+
+	if len(files) == 0 && !conflict.Exists {
+		companions = companions[1:]
+	}
+
+	for _, r := range companions {
+		r.companion.Action = ActionLeave
+	}
+
+	conflict.renames = files
+}
+
+//numbers the new names of the files in conflict, the first file keeps its
+//name unless it already exists
+func (f *Filer) suffix(conflict *Conflict) {
+
+	renames := conflict.renames
+	if !conflict.Exists {
+		renames = renames[1:]
+	}
+
+	for _, r := range renames {
+		name := r.file.NewName
+		for n := 2; ; n++ {
+			r.file.NewName = fmt.Sprintf("%s (%d)", name, n)
+			if !f.taken(r) {
+				break
+			}
+		}
+	}
+}
+
+//returns true if the new path of r exists or is the new path of another file
+func (f *Filer) taken(r *rename) bool {
+
+	path := r.file.GetNewPath(r.dir)
+	if _, err := os.Stat(path); err == nil {
+		return true
+	}
+
+	for _, dir := range f.GetDirs() {
+		for _, file := range f.files[dir] {
+			if file != r.file && file.NewName != "" && file.GetNewPath(dir) == path {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+//returns true if the file at a is higher quality than the file at b, or
+//the same quality but larger. Files of unknown quality are compared by size
+//only, as they're likely already named, not of low quality.
+func better(a, b string, quality QualityFunc) bool {
+
+	qa, qb := quality(a), quality(b)
+	if qa != qb && qa != 0 && qb != 0 {
+		return qa > qb
+	}
+
+	return size(a) > size(b)
+}
+
+func size(path string) int64 {
+
+	if info, err := os.Stat(path); err == nil {
+		return info.Size()
+	}
+
+	return 0
+}
+
+func skip(renames []*rename) {
+
+	for _, r := range renames {
+		r.file.NewName = ""
+		r.file.NewDir = ""
+		r.file.Overwrite = false
+	}
+}
+
+//describes the conflict for one of its files, as shown in diffs
+func (c *Conflict) describe(file *File) string {
+
+	var reasons []string
+	if c.Exists {
+		reasons = append(reasons, "target already exists")
+	}
+
+	for _, r := range c.renames {
+		if r.file != file {
+			reasons = append(reasons, fmt.Sprintf("same name as %s", r.source()))
+		}
+	}
+
+	return strings.Join(reasons, ", ")
+}
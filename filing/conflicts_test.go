@@ -0,0 +1,297 @@
+package filing
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+//scores files by their name, REPACK releases being better and files already
+//named, e.g. "Show - 1x1 - A", being of unknown quality
+func testQuality(path string) int {
+	switch name := filepath.Base(path); {
+	case strings.Contains(name, " - "):
+		return 0
+	case strings.Contains(name, "REPACK"):
+		return 2
+	}
+	return 1
+}
+
+func TestFiler_ResolveConflicts(t *testing.T) {
+
+	var tests = []struct {
+		name     string
+		strategy Strategy
+		existing []string          //files already named
+		renames  map[string]string //old name: new name
+		sizes    map[string]int64  //name: size, files are otherwise empty
+		expected map[string]string //old name: new name and overwrite
+	}{
+		{
+			name:     "Skip",
+			strategy: StrategySkip,
+			existing: []string{"Show - 1x2 - B.mkv"},
+			renames: map[string]string{
+				"Show.S01E01.mkv":        "Show - 1x1 - A",
+				"Show.S01E01.REPACK.mkv": "Show - 1x1 - A",
+				"Show.S01E02.mkv":        "Show - 1x2 - B",
+				"Show.S01E03.mkv":        "Show - 1x3 - C",
+			},
+			expected: map[string]string{
+				"Show.S01E01.mkv":        "",
+				"Show.S01E01.REPACK.mkv": "",
+				"Show.S01E02.mkv":        "",
+				"Show.S01E03.mkv":        "Show - 1x3 - C",
+			},
+		},
+		{
+			name:     "Suffix",
+			strategy: StrategySuffix,
+			existing: []string{"Show - 1x2 - B.mkv"},
+			renames: map[string]string{
+				"Show.S01E01.mkv":        "Show - 1x1 - A",
+				"Show.S01E01.REPACK.mkv": "Show - 1x1 - A",
+				"Show.S01E02.mkv":        "Show - 1x2 - B",
+			},
+			expected: map[string]string{
+				"Show.S01E01.REPACK.mkv": "Show - 1x1 - A",
+				"Show.S01E01.mkv":        "Show - 1x1 - A (2)",
+				"Show.S01E02.mkv":        "Show - 1x2 - B (2)",
+			},
+		},
+		{
+			name:     "Quality",
+			strategy: StrategyQuality,
+			existing: []string{"Show - 1x2 - B.mkv"},
+			renames: map[string]string{
+				"Show.S01E01.mkv":        "Show - 1x1 - A",
+				"Show.S01E01.REPACK.mkv": "Show - 1x1 - A",
+				"Show.S01E02.mkv":        "Show - 1x2 - B",
+				"Show.S01E03.REPACK.mkv": "Show - 1x2 - B",
+			},
+			sizes: map[string]int64{
+				"Show.S01E03.REPACK.mkv": 10, //existing is of unknown quality, so compared by size
+			},
+			expected: map[string]string{
+				"Show.S01E01.mkv":        "",
+				"Show.S01E01.REPACK.mkv": "Show - 1x1 - A",
+				"Show.S01E02.mkv":        "",
+				"Show.S01E03.REPACK.mkv": "Show - 1x2 - B (overwrite)",
+			},
+		},
+		{
+			name:     "Quality Smaller Than Existing",
+			strategy: StrategyQuality,
+			existing: []string{"Show - 1x2 - B.mkv"},
+			renames: map[string]string{
+				"Show.S01E02.REPACK.mkv": "Show - 1x2 - B",
+			},
+			sizes: map[string]int64{
+				"Show - 1x2 - B.mkv": 10,
+			},
+			expected: map[string]string{
+				"Show.S01E02.REPACK.mkv": "",
+			},
+		},
+		{
+			name:     "Replace",
+			strategy: StrategyReplace,
+			existing: []string{"Show - 1x2 - B.mkv"},
+			renames: map[string]string{
+				"Show.S01E02.mkv": "Show - 1x2 - B",
+			},
+			expected: map[string]string{
+				"Show.S01E02.mkv": "Show - 1x2 - B (overwrite)",
+			},
+		},
+		{
+			name:     "Already Named",
+			strategy: StrategyQuality,
+			renames: map[string]string{
+				"Show - 1x1 - A.mkv":     "Show - 1x1 - A",
+				"Show.S01E01.REPACK.mkv": "Show - 1x1 - A",
+			},
+			expected: map[string]string{
+				"Show - 1x1 - A.mkv":     "Show - 1x1 - A", //unknown quality, but no smaller
+				"Show.S01E01.REPACK.mkv": "",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		dir, err := ioutil.TempDir("", "media-mapper-filing")
+		if err != nil {
+			t.Fatalf("unable to create dir: %s", err.Error())
+		}
+		defer os.RemoveAll(dir)
+
+		filer := &Filer{
+			root:  dir,
+			files: map[string][]*File{},
+		}
+
+		for _, name := range test.existing {
+			writeFile(t, filepath.Join(dir, name))
+		}
+
+		//files are listed in name order, as when found by the filer
+		var names []string
+		for old := range test.renames {
+			names = append(names, old)
+		}
+		sort.Strings(names)
+
+		for _, old := range names {
+			new := test.renames[old]
+			writeFile(t, filepath.Join(dir, old))
+
+			ext := filepath.Ext(old)
+			filer.files[dir] = append(filer.files[dir], &File{
+				Name:    strings.TrimSuffix(old, ext),
+				NewName: new,
+				Ext:     ext,
+			})
+		}
+
+		for name, size := range test.sizes {
+			if err := os.Truncate(filepath.Join(dir, name), size); err != nil {
+				t.Fatalf("unable to resize file: %s", err.Error())
+			}
+		}
+
+		filer.ResolveConflicts(filer.FindConflicts(), test.strategy, testQuality)
+
+		actual := make(map[string]string)
+		for _, file := range filer.files[dir] {
+			actual[file.GetName()] = file.NewName
+			if file.Overwrite {
+				actual[file.GetName()] += " (overwrite)"
+			}
+		}
+
+		if diff := pretty.Compare(test.expected, actual); diff != "" {
+			t.Errorf("%s unexpected diff (-want +got):\n%s", test.name, diff)
+		}
+
+		if conflicts := filer.FindConflicts(); len(conflicts) > 0 {
+			t.Errorf("%s expected conflicts to be resolved, %d remain", test.name, len(conflicts))
+		}
+	}
+}
+
+func writeFile(t *testing.T, path string) {
+	if err := ioutil.WriteFile(path, nil, 0644); err != nil {
+		t.Fatalf("unable to create file: %s", err.Error())
+	}
+}
+
+func TestFiler_ResolveConflicts_Companions(t *testing.T) {
+
+	var tests = []struct {
+		name     string
+		strategy Strategy
+		existing []string
+		expected map[string]string //file or subtitle: new name or companion action
+	}{
+		{
+			name:     "Skip",
+			strategy: StrategySkip,
+			expected: map[string]string{
+				"Show.S01E01.mkv":        "",
+				"Show.S01E01.en.srt":     "rename",
+				"Show.S01E01.mp4":        "",
+				"Show.S01E01.mp4.en.srt": "rename",
+			},
+		},
+		{
+			name:     "Suffix",
+			strategy: StrategySuffix,
+			expected: map[string]string{
+				"Show.S01E01.mkv":        "Show - 1x1 - A",
+				"Show.S01E01.en.srt":     "rename",
+				"Show.S01E01.mp4":        "Show - 1x1 - A",
+				"Show.S01E01.mp4.en.srt": "leave",
+			},
+		},
+		{
+			name:     "Quality, Subtitles Exist",
+			strategy: StrategyQuality,
+			existing: []string{"Show - 1x1 - A.en.srt"},
+			expected: map[string]string{
+				"Show.S01E01.mkv":        "Show - 1x1 - A",
+				"Show.S01E01.en.srt":     "leave",
+				"Show.S01E01.mp4":        "Show - 1x1 - A",
+				"Show.S01E01.mp4.en.srt": "leave",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		dir, err := ioutil.TempDir("", "media-mapper-filing")
+		if err != nil {
+			t.Fatalf("unable to create dir: %s", err.Error())
+		}
+		defer os.RemoveAll(dir)
+
+		filer := &Filer{
+			root:  dir,
+			files: map[string][]*File{},
+		}
+
+		for _, name := range test.existing {
+			writeFile(t, filepath.Join(dir, name))
+		}
+
+		//files of the same episode with different extensions, so different
+		//new names, but subtitles that would share one
+		subtitles := map[string]string{
+			".mkv": "Show.S01E01.en.srt",
+			".mp4": "Show.S01E01.mp4.en.srt",
+		}
+		companions := make(map[string]*Companion)
+
+		for _, ext := range []string{".mkv", ".mp4"} {
+			writeFile(t, filepath.Join(dir, "Show.S01E01"+ext))
+			writeFile(t, filepath.Join(dir, subtitles[ext]))
+
+			companion := &Companion{
+				Path:   filepath.Join(dir, subtitles[ext]),
+				Action: ActionRename,
+				Suffix: ".en",
+				Ext:    ".srt",
+			}
+			companions[subtitles[ext]] = companion
+
+			filer.files[dir] = append(filer.files[dir], &File{
+				Name:       "Show.S01E01",
+				NewName:    "Show - 1x1 - A",
+				Ext:        ext,
+				Companions: []*Companion{companion},
+			})
+		}
+
+		filer.ResolveConflicts(filer.FindConflicts(), test.strategy, testQuality)
+
+		actual := make(map[string]string)
+		for _, file := range filer.files[dir] {
+			actual[file.GetName()] = file.NewName
+		}
+		for name, companion := range companions {
+			actual[name] = string(companion.Action)
+		}
+
+		if diff := pretty.Compare(test.expected, actual); diff != "" {
+			t.Errorf("%s unexpected diff (-want +got):\n%s", test.name, diff)
+		}
+
+		if conflicts := filer.FindConflicts(); len(conflicts) > 0 {
+			t.Errorf("%s expected conflicts to be resolved, %d remain", test.name, len(conflicts))
+		}
+	}
+}
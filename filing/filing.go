@@ -6,6 +6,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 
@@ -39,10 +40,27 @@ func New(root string) (*Filer, error) {
 	return filer, nil
 }
 
+func (f *Filer) GetRoot() string {
+	return f.root
+}
+
 func (f *Filer) GetFiles() map[string][]*File {
 	return f.files
 }
 
+//returns the directories containing media files, sorted so they're always
+//processed in the same order
+func (f *Filer) GetDirs() []string {
+
+	dirs := make([]string, 0, len(f.files))
+	for dir := range f.files {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	return dirs
+}
+
 //returns map, with folder location as key, relevant contained files as values (array)
 func (f *Filer) findFiles() error {
 
@@ -71,6 +89,8 @@ func (f *Filer) findFiles() error {
 	}
 
 	f.files = fileMap
+	f.attachSubtitles(files)
+
 	return nil
 }
 
@@ -102,45 +122,136 @@ func (f *Filer) extractMediaFiles(files []string) []string {
 	for _, file := range files {
 		extension := filepath.Ext(file)
 
-		if _, ok := supportedVideo[strings.ToLower(extension)]; ok {
+		if _, ok := supportedVideo[strings.ToLower(extension)]; ok && !isSample(file) {
 			mediaFiles = append(mediaFiles, file)
 		}
 	}
 
-	//TODO same for supportedSubtitle files
-
 	return mediaFiles
 }
 
-func (f *Filer) RenameBatch() {
+//RenameBatch renames (and moves) each file with a new name, along with its
+//companions, returning the run of renames made so it can be recorded in a
+//journal. Existing files are never overwritten, unless the file renamed to
+//its path has Overwrite set, so conflicts should be resolved first.
+func (f *Filer) RenameBatch() *Run {
+
+	run := newRun()
 
-	for loc, dir := range f.files {
-		for _, file := range dir {
+	for _, loc := range f.GetDirs() {
+		for _, file := range f.files[loc] {
 
 			if file.NewName == "" {
 				continue
 			}
 
 			old := path.Join(loc, file.GetName())
-			new := path.Join(loc, file.GetNewName())
+			new := file.GetNewPath(loc)
+			if filepath.Clean(old) != filepath.Clean(new) {
+
+				//create destination when organising
+				if file.IsMoved(loc) {
+					missing := missingDirs(file.NewDir)
+					if err := os.MkdirAll(file.NewDir, 0755); err != nil {
+						log.Println(fmt.Sprintf("Failed to create directory %s with error: %s", file.NewDir, err.Error()))
+						continue
+					}
+					for _, dir := range missing {
+						run.Created = append(run.Created, Absolute(dir))
+					}
+				}
+
+				if !renameFile(old, new, file.Overwrite, run) {
+					continue
+				}
+			}
+
+			//companions follow the file, even if it's already named
+			for _, companion := range file.Companions {
+				if companion.Action == ActionDelete {
+					if err := os.Remove(companion.Path); err != nil {
+						log.Println(fmt.Sprintf("Failed to delete file: %s", companion.Path))
+						continue
+					}
+					run.Deleted = append(run.Deleted, Absolute(companion.Path))
+					continue
+				}
+
+				new := companion.GetNewPath(loc, file)
+				if filepath.Clean(companion.Path) == filepath.Clean(new) {
+					continue
+				}
+
+				//moved companions may keep a folder of their own, e.g. Sample
+				missing := missingDirs(filepath.Dir(new))
+				if err := os.MkdirAll(filepath.Dir(new), 0755); err != nil {
+					log.Println(fmt.Sprintf("Failed to create directory %s with error: %s", filepath.Dir(new), err.Error()))
+					continue
+				}
+				for _, dir := range missing {
+					run.Created = append(run.Created, Absolute(dir))
+				}
+
+				renameFile(companion.Path, new, false, run)
+			}
+		}
+	}
 
-			err := os.Rename(old, new)
+	return run
+}
 
-			if err != nil {
-				log.Println(fmt.Sprintf("Failed to rename file: %s", old))
+//renames old to new, recording it in run, and returns true if successful.
+//An existing file at new is only replaced if overwrite is set.
+func renameFile(old, new string, overwrite bool, run *Run) bool {
+
+	replaced := false
+	if target, err := os.Stat(new); err == nil {
+		source, err := os.Stat(old)
+		if err == nil && !os.SameFile(source, target) {
+			if !overwrite {
+				log.Println(fmt.Sprintf("Failed to rename file: %s - %s already exists", old, new))
+				return false
 			}
+			replaced = true
 		}
 	}
+
+	if err := moveFile(old, new); err != nil {
+		log.Println(fmt.Sprintf("Failed to rename file: %s with error: %s", old, err.Error()))
+		return false
+	}
+
+	//journal is used from any working directory
+	renamed := &Renamed{
+		Old:      Absolute(old),
+		New:      Absolute(new),
+		Replaced: replaced,
+	}
+	if info, err := os.Stat(new); err == nil {
+		renamed.Size = info.Size()
+		renamed.ModTime = info.ModTime()
+	}
+	run.Renamed = append(run.Renamed, renamed)
+
+	return true
 }
 
 func (f *Filer) PrintBatchDiff() {
 
+	//conflicting files are marked with the reason
+	conflicts := make(map[*File]*Conflict)
+	for _, conflict := range f.FindConflicts() {
+		for _, r := range conflict.renames {
+			conflicts[r.file] = conflict
+		}
+	}
+
 	//print location of diffs
-	for loc, dir := range f.files {
+	for _, loc := range f.GetDirs() {
 		var once sync.Once //only want to print loc once per directory
 
 		//print diff
-		for _, file := range dir {
+		for _, file := range f.files[loc] {
 			if file.NewName == "" {
 				continue
 			}
@@ -151,8 +262,49 @@ func (f *Filer) PrintBatchDiff() {
 			})
 
 			colour.Red("- %s", file.GetName())
-			colour.Green("+ %s", file.GetNewName())
+			colour.Green("+ %s", f.DisplayNewName(loc, file))
+			if conflict, ok := conflicts[file]; ok {
+				colour.Yellow("! conflict: %s", conflict.describe(file))
+			} else if file.Overwrite {
+				colour.Yellow("! replaces existing file")
+			}
+			for _, companion := range file.Companions {
+				if companion.Action == ActionDelete {
+					colour.Red("  x %s (delete)", f.displayPath(loc, companion.Path))
+				} else if new := companion.GetNewPath(loc, file); filepath.Clean(companion.Path) != filepath.Clean(new) {
+					colour.Red("  - %s", f.displayPath(loc, companion.Path))
+					colour.Green("  + %s (%s)", f.displayPath(loc, new), companion.Action)
+				}
+			}
 			fmt.Println()
 		}
 	}
 }
+
+//DisplayNewName returns the new name of the file in loc as shown in diffs,
+//which is its new path (relative to the root, where possible) if it's moved
+func (f *Filer) DisplayNewName(loc string, file *File) string {
+
+	if !file.IsMoved(loc) {
+		return file.GetNewName()
+	}
+
+	newPath := file.GetNewPath(loc)
+	if rel, err := filepath.Rel(f.root, newPath); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+
+	return newPath
+}
+
+//returns path as shown in diffs, relative to loc or the root where possible
+func (f *Filer) displayPath(loc string, path string) string {
+
+	for _, dir := range []string{loc, f.root} {
+		if rel, err := filepath.Rel(dir, path); err == nil && !strings.HasPrefix(rel, "..") {
+			return rel
+		}
+	}
+
+	return path
+}
@@ -0,0 +1,251 @@
+package filing
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	journalDir  = "media-mapper/journal"
+	journalExt  = ".json"
+	runIDFormat = "20060102-150405.000"
+)
+
+//Renamed is a file renamed (or moved) by a batch rename. Its size and
+//modification time are kept so undoing can check it hasn't since changed.
+type Renamed struct {
+	Old      string    `json:"old"`
+	New      string    `json:"new"`
+	Size     int64     `json:"size"`
+	ModTime  time.Time `json:"modTime"`
+	Replaced bool      `json:"replaced"` //an existing file was replaced, which can't be restored
+	Undone   bool      `json:"undone"`
+}
+
+//Run is a batch rename, as recorded in the journal
+type Run struct {
+	ID      string     `json:"id"`
+	Time    time.Time  `json:"time"`
+	Renamed []*Renamed `json:"renamed"`
+	Created []string   `json:"created"` //directories created, parents first
+	Written []string   `json:"written"` //files written alongside the renames, such as nfo files
+	Deleted []string   `json:"deleted"` //sidecar files deleted, which can't be restored
+}
+
+func newRun() *Run {
+
+	now := time.Now()
+
+	return &Run{
+		ID:   now.Format(runIDFormat),
+		Time: now,
+	}
+}
+
+//Pending returns the number of renames in the run not yet undone, and of
+//files written not yet removed
+func (r *Run) Pending() int {
+
+	pending := len(r.Written)
+	for _, renamed := range r.Renamed {
+		if !renamed.Undone {
+			pending++
+		}
+	}
+
+	return pending
+}
+
+//Journal stores each batch rename as a file in dir, so that it can be undone
+type Journal struct {
+	dir string
+}
+
+func NewJournal(dir string) *Journal {
+	return &Journal{
+		dir: dir,
+	}
+}
+
+//DefaultJournalDir returns the journal directory under the user's config dir
+func DefaultJournalDir() (string, error) {
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, filepath.FromSlash(journalDir)), nil
+}
+
+//Record writes (or rewrites) the run to the journal
+func (j *Journal) Record(run *Run) error {
+
+	data, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(j.dir, 0755); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(j.path(run.ID), data, 0644)
+}
+
+//Runs returns the recorded runs, most recent first
+func (j *Journal) Runs() ([]*Run, error) {
+
+	files, err := ioutil.ReadDir(j.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil //nothing recorded yet
+		}
+		return nil, err
+	}
+
+	var runs []*Run
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != journalExt {
+			continue
+		}
+
+		run, err := j.Get(strings.TrimSuffix(file.Name(), journalExt))
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+
+	sort.Slice(runs, func(i, k int) bool {
+		return runs[i].Time.After(runs[k].Time)
+	})
+
+	return runs, nil
+}
+
+//Get returns the run with the given ID
+func (j *Journal) Get(id string) (*Run, error) {
+
+	data, err := ioutil.ReadFile(j.path(id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no run with ID %s", id)
+		}
+		return nil, err
+	}
+
+	var run *Run
+	if err := json.Unmarshal(data, &run); err != nil {
+		return nil, fmt.Errorf("failed reading run %s - %s", id, err.Error())
+	}
+
+	return run, nil
+}
+
+//Undo restores the files renamed in run, most recent first, and removes the
+//files written, then removes the directories the run created if they're
+//empty. Files that have since been changed or moved, or whose old path is
+//now taken, are left alone and reported as errors, so the run can be undone
+//again once they're sorted. The journal is updated with the files restored.
+func (j *Journal) Undo(run *Run) []error {
+
+	var errs []error
+	for i := len(run.Renamed) - 1; i >= 0; i-- {
+		renamed := run.Renamed[i]
+		if renamed.Undone {
+			continue
+		}
+
+		if err := restore(renamed); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %s", renamed.New, err.Error()))
+			continue
+		}
+		renamed.Undone = true
+	}
+
+	var written []string
+	for _, path := range run.Written {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			errs = append(errs, fmt.Errorf("%s: %s", path, err.Error()))
+			written = append(written, path)
+		}
+	}
+	run.Written = written
+
+	for i := len(run.Created) - 1; i >= 0; i-- {
+		os.Remove(run.Created[i]) //only succeeds if empty
+	}
+
+	if err := j.Record(run); err != nil {
+		errs = append(errs, fmt.Errorf("failed updating journal - %s", err.Error()))
+	}
+
+	return errs
+}
+
+//moves a renamed file back, if it's unchanged and its old path is free
+func restore(renamed *Renamed) error {
+
+	info, err := os.Stat(renamed.New)
+	if err != nil {
+		return fmt.Errorf("no longer exists")
+	}
+
+	if info.Size() != renamed.Size || !info.ModTime().Equal(renamed.ModTime) {
+		return fmt.Errorf("changed since renamed")
+	}
+
+	if _, err := os.Lstat(renamed.Old); err == nil {
+		return fmt.Errorf("%s already exists", renamed.Old)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(renamed.Old), 0755); err != nil {
+		return err
+	}
+
+	return moveFile(renamed.New, renamed.Old)
+}
+
+func (j *Journal) path(id string) string {
+	return filepath.Join(j.dir, id+journalExt)
+}
+
+//returns the directories MkdirAll would create for dir, parents first
+func missingDirs(dir string) []string {
+
+	var missing []string
+	for {
+		if _, err := os.Stat(dir); err == nil {
+			break
+		}
+
+		missing = append([]string{dir}, missing...)
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	return missing
+}
+
+//Absolute returns the absolute path of path, as paths are recorded in the
+//journal so it can be used from any working directory, or path if it can't
+//be made absolute
+func Absolute(path string) string {
+
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+
+	return path
+}
@@ -0,0 +1,160 @@
+package filing
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestJournal_Undo(t *testing.T) {
+
+	root, err := ioutil.TempDir("", "media-mapper-journal")
+	if err != nil {
+		t.Fatalf("unable to create dir: %s", err.Error())
+	}
+	defer os.RemoveAll(root)
+
+	downloads := filepath.Join(root, "downloads")
+	library := filepath.Join(root, "library")
+	if err := os.MkdirAll(downloads, 0755); err != nil {
+		t.Fatalf("unable to create dir: %s", err.Error())
+	}
+
+	for _, name := range []string{"Show.S01E01.mkv", "Show.S01E02.mkv", "Movie.2010.mkv"} {
+		writeFile(t, filepath.Join(downloads, name))
+	}
+
+	filer, err := New(downloads)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	newNames := map[string]*File{
+		"Show.S01E01": {NewName: "Show - 1x1 - A", NewDir: filepath.Join(library, "Show", "Season 01")},
+		"Show.S01E02": {NewName: "Show - 1x2 - B", NewDir: filepath.Join(library, "Show", "Season 01")},
+		"Movie.2010":  {NewName: "Movie (2010)"},
+	}
+	for _, file := range filer.GetFiles()[downloads] {
+		file.NewName = newNames[file.Name].NewName
+		file.NewDir = newNames[file.Name].NewDir
+	}
+
+	journal := NewJournal(filepath.Join(root, "journal"))
+
+	run := filer.RenameBatch()
+
+	//files written alongside the renames are removed
+	written := filepath.Join(downloads, "Movie (2010).nfo")
+	writeFile(t, written)
+	run.Written = append(run.Written, written)
+
+	if err := journal.Record(run); err != nil {
+		t.Fatalf("unexpected error recording run: %s", err.Error())
+	}
+
+	//a changed file isn't restored
+	changed := filepath.Join(library, "Show", "Season 01", "Show - 1x2 - B.mkv")
+	if err := ioutil.WriteFile(changed, []byte("changed"), 0644); err != nil {
+		t.Fatalf("unable to change file: %s", err.Error())
+	}
+
+	runs, err := journal.Runs()
+	if err != nil || len(runs) != 1 {
+		t.Fatalf("expected 1 recorded run, got %d (%v)", len(runs), err)
+	}
+
+	if errs := journal.Undo(runs[0]); len(errs) != 1 {
+		t.Errorf("expected 1 error for the changed file, got %v", errs)
+	}
+
+	expected := []string{
+		"downloads/Movie.2010.mkv",
+		"downloads/Show.S01E01.mkv",
+		"library/Show/Season 01/Show - 1x2 - B.mkv",
+	}
+	if diff := pretty.Compare(expected, listFiles(t, root)); diff != "" {
+		t.Errorf("Undo unexpected diff (-want +got):\n%s", diff)
+	}
+
+	//journal records what's left to undo
+	run, err = journal.Get(run.ID)
+	if err != nil {
+		t.Fatalf("unexpected error reading run: %s", err.Error())
+	}
+	if run.Pending() != 1 {
+		t.Errorf("expected 1 rename left to undo, got %d", run.Pending())
+	}
+}
+
+func TestJournal_Undo_Written(t *testing.T) {
+
+	root, err := ioutil.TempDir("", "media-mapper-journal")
+	if err != nil {
+		t.Fatalf("unable to create dir: %s", err.Error())
+	}
+	defer os.RemoveAll(root)
+
+	//already named files rerun to write metadata only
+	written := filepath.Join(root, "Movie (2010).nfo")
+	writeFile(t, filepath.Join(root, "Movie (2010).mkv"))
+	writeFile(t, written)
+
+	run := newRun()
+	run.Written = append(run.Written, written)
+
+	journal := NewJournal(filepath.Join(root, "journal"))
+	if err := journal.Record(run); err != nil {
+		t.Fatalf("unexpected error recording run: %s", err.Error())
+	}
+
+	if run.Pending() != 1 {
+		t.Errorf("expected 1 written file to undo, got %d", run.Pending())
+	}
+
+	if errs := journal.Undo(run); len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+
+	if diff := pretty.Compare([]string{"Movie (2010).mkv"}, listFiles(t, root)); diff != "" {
+		t.Errorf("Undo unexpected diff (-want +got):\n%s", diff)
+	}
+
+	run, err = journal.Get(run.ID)
+	if err != nil {
+		t.Fatalf("unexpected error reading run: %s", err.Error())
+	}
+	if run.Pending() != 0 {
+		t.Errorf("expected nothing left to undo, got %d", run.Pending())
+	}
+}
+
+//lists the files under root, other than the journal, relative to root
+func listFiles(t *testing.T, root string) []string {
+
+	var files []string
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() && info.Name() == "journal" {
+			return filepath.SkipDir
+		}
+
+		if !info.IsDir() {
+			rel, _ := filepath.Rel(root, path)
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to list files: %s", err.Error())
+	}
+
+	sort.Strings(files)
+	return files
+}
@@ -0,0 +1,77 @@
+package filing
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+//moves the file at old to new, replacing any file there. Files moved to
+//another filesystem, as when organising into a library on another drive,
+//are copied then removed, as they can't be renamed.
+func moveFile(old, new string) error {
+
+	err := os.Rename(old, new)
+	if err == nil || !errors.Is(err, syscall.EXDEV) {
+		return err
+	}
+
+	if err := copyFile(old, new); err != nil {
+		return err
+	}
+
+	//the copy is removed rather than leaving the file in both places
+	if err := os.Remove(old); err != nil {
+		os.Remove(new)
+		return err
+	}
+
+	return nil
+}
+
+//copies the file at old to new, replacing any file there, keeping its mode
+//and modification time. The copy is written to a temporary file beside new
+//and synced before it's renamed, so a failed copy leaves nothing behind.
+func copyFile(old, new string) error {
+
+	source, err := os.Open(old)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	info, err := source.Stat()
+	if err != nil {
+		return err
+	}
+
+	temp, err := ioutil.TempFile(filepath.Dir(new), ".media-mapper-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name()) //fails once renamed
+
+	_, err = io.Copy(temp, source)
+	if err == nil {
+		err = temp.Sync()
+	}
+	if closeErr := temp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := os.Chmod(temp.Name(), info.Mode()); err != nil {
+		return err
+	}
+
+	if err := os.Chtimes(temp.Name(), info.ModTime(), info.ModTime()); err != nil {
+		return err
+	}
+
+	return os.Rename(temp.Name(), new)
+}
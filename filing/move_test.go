@@ -0,0 +1,78 @@
+package filing
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestCopyFile(t *testing.T) {
+
+	root, err := ioutil.TempDir("", "media-mapper-move")
+	if err != nil {
+		t.Fatalf("unable to create dir: %s", err.Error())
+	}
+	defer os.RemoveAll(root)
+
+	old := filepath.Join(root, "Movie.2010.mkv")
+	new := filepath.Join(root, "library", "Movie (2010).mkv")
+	if err := ioutil.WriteFile(old, []byte("movie"), 0600); err != nil {
+		t.Fatalf("unable to create file: %s", err.Error())
+	}
+	if err := os.MkdirAll(filepath.Dir(new), 0755); err != nil {
+		t.Fatalf("unable to create dir: %s", err.Error())
+	}
+	writeFile(t, new) //replaced
+
+	modTime := time.Date(2010, 7, 30, 12, 0, 0, 0, time.UTC)
+	if err := os.Chtimes(old, modTime, modTime); err != nil {
+		t.Fatalf("unable to set modification time: %s", err.Error())
+	}
+
+	if err := copyFile(old, new); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	//copied beside the original, without leaving a temporary file
+	expected := []string{"Movie.2010.mkv", "library/Movie (2010).mkv"}
+	if diff := pretty.Compare(expected, listFiles(t, root)); diff != "" {
+		t.Errorf("copyFile unexpected diff (-want +got):\n%s", diff)
+	}
+
+	data, err := ioutil.ReadFile(new)
+	if err != nil || string(data) != "movie" {
+		t.Errorf("expected copied contents %q, got %q (%v)", "movie", data, err)
+	}
+
+	info, err := os.Stat(new)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if info.Mode().Perm() != 0600 || !info.ModTime().Equal(modTime) {
+		t.Errorf("expected mode %s and time %s, got %s and %s", os.FileMode(0600), modTime, info.Mode().Perm(), info.ModTime())
+	}
+}
+
+func TestMoveFile(t *testing.T) {
+
+	root, err := ioutil.TempDir("", "media-mapper-move")
+	if err != nil {
+		t.Fatalf("unable to create dir: %s", err.Error())
+	}
+	defer os.RemoveAll(root)
+
+	old := filepath.Join(root, "Movie.2010.mkv")
+	writeFile(t, old)
+
+	if err := moveFile(old, filepath.Join(root, "Movie (2010).mkv")); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if diff := pretty.Compare([]string{"Movie (2010).mkv"}, listFiles(t, root)); diff != "" {
+		t.Errorf("moveFile unexpected diff (-want +got):\n%s", diff)
+	}
+}
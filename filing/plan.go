@@ -0,0 +1,231 @@
+package filing
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	planVersion = 1
+)
+
+//Plan is a batch of renames that can be saved, inspected or edited, and
+//applied later
+type Plan struct {
+	Version int         `json:"version"`
+	Created time.Time   `json:"created"`
+	Root    string      `json:"root"`
+	Items   []*PlanItem `json:"items"`
+}
+
+//PlanItem is the proposed rename of a single file. The source file's size
+//and modification time are kept to check it's unchanged when applied.
+type PlanItem struct {
+	Source      string    `json:"source"`
+	Destination string    `json:"destination"` //empty if the file isn't renamed
+	Overwrite   bool      `json:"overwrite,omitempty"`
+	Provider    string    `json:"provider,omitempty"`
+	ID          int       `json:"id,omitempty"` //ID of the matched movie or show in the provider's database
+	Confidence  float64   `json:"confidence"`
+	Error       string    `json:"error,omitempty"`
+	Size        int64     `json:"size"`
+	ModTime     time.Time `json:"modTime"`
+
+	Companions []*PlanCompanion `json:"companions,omitempty"`
+}
+
+//PlanCompanion is the proposed rename, move or deletion of a companion of a
+//file, such as its subtitles, which must stay alongside the file
+type PlanCompanion struct {
+	Source      string `json:"source"`
+	Destination string `json:"destination"` //empty if the companion is deleted
+	Action      Action `json:"action"`
+}
+
+func NewPlan(root string) *Plan {
+	return &Plan{
+		Version: planVersion,
+		Created: time.Now(),
+		Root:    Absolute(root),
+	}
+}
+
+//NewPlanItem returns the plan item for the rename of file in dir
+func NewPlanItem(dir string, file *File) *PlanItem {
+
+	item := &PlanItem{
+		Source:    Absolute(filepath.Join(dir, file.GetName())),
+		Overwrite: file.Overwrite,
+	}
+
+	if file.NewName != "" {
+		item.Destination = Absolute(file.GetNewPath(dir))
+	}
+
+	for _, companion := range file.Companions {
+		c := &PlanCompanion{
+			Source: Absolute(companion.Path),
+			Action: companion.Action,
+		}
+		if file.NewName != "" && companion.Action != ActionDelete {
+			c.Destination = Absolute(companion.GetNewPath(dir, file))
+		}
+		item.Companions = append(item.Companions, c)
+	}
+
+	if info, err := os.Stat(item.Source); err == nil {
+		item.Size = info.Size()
+		item.ModTime = info.ModTime()
+	}
+
+	return item
+}
+
+func (p *Plan) Write(writer io.Writer) error {
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(p)
+}
+
+func ReadPlan(reader io.Reader) (*Plan, error) {
+
+	var plan *Plan
+	if err := json.NewDecoder(reader).Decode(&plan); err != nil {
+		return nil, err
+	}
+
+	if plan == nil || plan.Version != planVersion {
+		return nil, fmt.Errorf("unsupported plan version")
+	}
+
+	return plan, nil
+}
+
+//NewFromPlan returns a Filer holding the renames of the plan, to be applied
+//with RenameBatch. Renames whose source file has changed since the plan was
+//made, or that are otherwise invalid, are left out and returned as errors.
+func NewFromPlan(plan *Plan) (*Filer, []error) {
+
+	filer := &Filer{
+		root:  plan.Root,
+		files: make(map[string][]*File),
+	}
+
+	var errs []error
+	for _, item := range plan.Items {
+		if item.Destination == "" {
+			continue
+		}
+
+		dir, file, err := item.file(plan.Root)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %s", item.Source, err.Error()))
+			continue
+		}
+
+		for _, companion := range item.Companions {
+			if err := companion.attach(plan.Root, file, dir); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %s", companion.Source, err.Error()))
+			}
+		}
+
+		filer.files[dir] = append(filer.files[dir], file)
+	}
+
+	return filer, errs
+}
+
+//validates the item and returns its rename as a File along with its
+//directory. Relative paths are taken to be relative to root.
+func (item *PlanItem) file(root string) (string, *File, error) {
+
+	source, destination := item.Source, item.Destination
+	if !filepath.IsAbs(source) {
+		source = filepath.Join(root, source)
+	}
+	if !filepath.IsAbs(destination) {
+		destination = filepath.Join(root, destination)
+	}
+
+	info, err := os.Stat(source)
+	if err != nil {
+		return "", nil, fmt.Errorf("no longer exists")
+	}
+
+	//modification time may have been removed from a hand written plan
+	if !item.ModTime.IsZero() && (info.Size() != item.Size || !info.ModTime().Equal(item.ModTime)) {
+		return "", nil, fmt.Errorf("changed since the plan was made")
+	}
+
+	ext := filepath.Ext(source)
+	name := filepath.Base(destination)
+	if !strings.HasSuffix(name, ext) || name == ext {
+		return "", nil, fmt.Errorf("destination %s must keep the extension %s", destination, ext)
+	}
+
+	file := &File{
+		Name:      strings.TrimSuffix(filepath.Base(source), ext),
+		NewName:   strings.TrimSuffix(name, ext),
+		Ext:       ext,
+		Overwrite: item.Overwrite,
+	}
+
+	if dir := filepath.Dir(destination); filepath.Clean(dir) != filepath.Clean(filepath.Dir(source)) {
+		file.NewDir = dir
+	}
+
+	return filepath.Dir(source), file, nil
+}
+
+//validates the companion and attaches it to file in dir. Its destination must
+//be alongside the file's, named after it if renamed.
+func (c *PlanCompanion) attach(root string, file *File, dir string) error {
+
+	source, destination := c.Source, c.Destination
+	if !filepath.IsAbs(source) {
+		source = filepath.Join(root, source)
+	}
+	if !filepath.IsAbs(destination) {
+		destination = filepath.Join(root, destination)
+	}
+
+	if _, err := os.Stat(source); err != nil {
+		return fmt.Errorf("no longer exists")
+	}
+
+	companion := &Companion{
+		Path:   source,
+		Action: c.Action,
+		Ext:    filepath.Ext(source),
+	}
+
+	switch c.Action {
+	case ActionDelete:
+	case ActionMove:
+		if expected := companion.GetNewPath(dir, file); destination != expected {
+			return fmt.Errorf("destination %s must be %s, moved with %s", destination, expected, file.GetNewName())
+		}
+	case ActionRename:
+		if filepath.Dir(destination) != filepath.Dir(file.GetNewPath(dir)) {
+			return fmt.Errorf("destination %s must be alongside %s", destination, file.GetNewPath(dir))
+		}
+
+		name := filepath.Base(destination)
+		if !strings.HasPrefix(name, file.NewName) || !strings.HasSuffix(name, companion.Ext) || len(name) < len(file.NewName)+len(companion.Ext) {
+			return fmt.Errorf("destination %s must be named %s followed by any tags and %s", destination, file.NewName, companion.Ext)
+		}
+		companion.Suffix = strings.TrimSuffix(strings.TrimPrefix(name, file.NewName), companion.Ext)
+	default:
+		return fmt.Errorf("unknown action %q", c.Action)
+	}
+
+	file.Companions = append(file.Companions, companion)
+	return nil
+}
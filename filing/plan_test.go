@@ -0,0 +1,83 @@
+package filing
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestPlan(t *testing.T) {
+
+	root, err := ioutil.TempDir("", "media-mapper-plan")
+	if err != nil {
+		t.Fatalf("unable to create dir: %s", err.Error())
+	}
+	defer os.RemoveAll(root)
+
+	for _, name := range []string{"Show.S01E01.mkv", "Show.S01E02.mkv", "Show.S01E03.mkv", "Show.S01E04.mkv", "Movie.2010.mkv", "Show.S01E01.en.srt"} {
+		writeFile(t, filepath.Join(root, name))
+	}
+
+	filer, err := New(root)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	newNames := map[string]string{
+		"Show.S01E01": "Show - 1x1 - A",
+		"Show.S01E02": "Show - 1x2 - B",
+		"Show.S01E03": "Show - 1x3 - C",
+		"Show.S01E04": "Show - 1x4 - D",
+	}
+
+	plan := NewPlan(root)
+	for _, file := range filer.GetFiles()[root] {
+		file.NewName = newNames[file.Name]
+		plan.Items = append(plan.Items, NewPlanItem(root, file))
+	}
+
+	var buf bytes.Buffer
+	if err := plan.Write(&buf); err != nil {
+		t.Fatalf("unexpected error writing plan: %s", err.Error())
+	}
+
+	plan, err = ReadPlan(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error reading plan: %s", err.Error())
+	}
+
+	for _, item := range plan.Items {
+		switch filepath.Base(item.Source) {
+		case "Show.S01E02.mkv": //changed since the plan was made
+			if err := ioutil.WriteFile(item.Source, []byte("changed"), 0644); err != nil {
+				t.Fatalf("unable to change file: %s", err.Error())
+			}
+		case "Show.S01E03.mkv": //edited by hand, relative to the root
+			item.Destination = filepath.Join("Show", "Show - 1x3 - Edited.mkv")
+		case "Show.S01E04.mkv": //extension can't be changed
+			item.Destination = filepath.Join(root, "Show - 1x4 - D.avi")
+		}
+	}
+
+	filer, errs := NewFromPlan(plan)
+	if len(errs) != 2 {
+		t.Errorf("expected 2 errors for the changed file and extension, got %v", errs)
+	}
+	filer.RenameBatch()
+
+	expected := []string{
+		"Movie.2010.mkv",
+		"Show - 1x1 - A.en.srt",
+		"Show - 1x1 - A.mkv",
+		"Show.S01E02.mkv",
+		"Show.S01E04.mkv",
+		"Show/Show - 1x3 - Edited.mkv",
+	}
+	if diff := pretty.Compare(expected, listFiles(t, root)); diff != "" {
+		t.Errorf("Apply unexpected diff (-want +got):\n%s", diff)
+	}
+}
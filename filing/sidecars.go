@@ -0,0 +1,155 @@
+package filing
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+//Sidecar is a kind of file found in releases alongside the media files
+type Sidecar string
+
+const (
+	SidecarNFO      Sidecar = "nfo"
+	SidecarArtwork  Sidecar = "artwork"
+	SidecarText     Sidecar = "text"
+	SidecarChecksum Sidecar = "checksum"
+	SidecarSample   Sidecar = "sample" //sample videos, which are never matched themselves
+)
+
+var (
+	//sidecar kinds by file extension, other than samples
+	sidecarExts = map[string]Sidecar{
+		".nfo":  SidecarNFO,
+		".jpg":  SidecarArtwork,
+		".jpeg": SidecarArtwork,
+		".png":  SidecarArtwork,
+		".tbn":  SidecarArtwork,
+		".txt":  SidecarText,
+		".sfv":  SidecarChecksum,
+		".md5":  SidecarChecksum,
+	}
+
+	//folders samples are kept in by releases, beside the media files
+	sampleDirs = map[string]struct{}{
+		"sample":  {},
+		"samples": {},
+	}
+)
+
+//Rules are the actions taken with each kind of sidecar when the media file
+//it belongs to is renamed
+type Rules map[Sidecar]Action
+
+//DefaultRules leaves all sidecars alone
+func DefaultRules() Rules {
+	return Rules{
+		SidecarNFO:      ActionLeave,
+		SidecarArtwork:  ActionLeave,
+		SidecarText:     ActionLeave,
+		SidecarChecksum: ActionLeave,
+		SidecarSample:   ActionLeave,
+	}
+}
+
+//Validate returns an error if a rule is for an unknown kind of sidecar or
+//action
+func (r Rules) Validate() error {
+
+	for sidecar, action := range r {
+		if _, ok := DefaultRules()[sidecar]; !ok {
+			return fmt.Errorf("unknown sidecar %q", sidecar)
+		}
+		if _, ok := Actions[string(action)]; !ok {
+			return fmt.Errorf("unknown action %q for sidecar %q", action, sidecar)
+		}
+	}
+
+	return nil
+}
+
+//AttachSidecars attaches the sidecar files under the root to the media file
+//each belongs to as companions, to be renamed, moved or deleted with it as
+//the rules say. Sidecars belong to a media file if they're named after it,
+//or are the only media file beside them (or beside their Sample folder).
+func (f *Filer) AttachSidecars(rules Rules) {
+
+	files, err := f.listAllFiles()
+	if err != nil {
+		log.Println(fmt.Sprintf("Failed to find sidecar files with error: %s", err.Error()))
+		return
+	}
+
+	for _, path := range files {
+		sidecar, ok := sidecarOf(path)
+		if !ok || rules[sidecar] == "" || rules[sidecar] == ActionLeave {
+			continue
+		}
+
+		file, rest := f.companionOf(path, sampleDirs)
+		if file == nil {
+			continue
+		}
+
+		companion := &Companion{
+			Path:   path,
+			Action: rules[sidecar],
+			Suffix: rest,
+			Ext:    filepath.Ext(path),
+		}
+
+		//the rest of the name is kept, unless it's just the release name
+		if rest == strings.TrimSuffix(filepath.Base(path), companion.Ext) {
+			switch sidecar {
+			case SidecarArtwork:
+				companion.Suffix = "-" + strings.ToLower(rest) //e.g. poster.jpg
+			default:
+				companion.Suffix = ""
+			}
+		}
+		if sidecar == SidecarSample {
+			companion.Suffix = "-sample"
+		}
+
+		file.Companions = append(file.Companions, companion)
+	}
+
+	f.numberCompanions()
+}
+
+//returns the kind of sidecar the file at path is, if any
+func sidecarOf(path string) (Sidecar, bool) {
+
+	ext := strings.ToLower(filepath.Ext(path))
+
+	if _, ok := supportedVideo[ext]; ok {
+		return SidecarSample, isSample(path)
+	}
+
+	sidecar, ok := sidecarExts[ext]
+	return sidecar, ok
+}
+
+//returns true if the video at path is a sample, either named as one or in a
+//Sample folder
+func isSample(path string) bool {
+
+	if isIn(filepath.Dir(path), sampleDirs) {
+		return true
+	}
+
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for _, word := range words {
+		if word == "sample" {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,81 @@
+package filing
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestFiler_AttachSidecars(t *testing.T) {
+
+	root, err := ioutil.TempDir("", "media-mapper-sidecars")
+	if err != nil {
+		t.Fatalf("unable to create dir: %s", err.Error())
+	}
+	defer os.RemoveAll(root)
+
+	downloads := filepath.Join(root, "downloads")
+	library := filepath.Join(root, "library")
+
+	for _, name := range []string{
+		"Movie.2010.1080p/Movie.2010.1080p.mkv",
+		"Movie.2010.1080p/movie.2010.1080p.nfo",
+		"Movie.2010.1080p/poster.jpg",
+		"Movie.2010.1080p/RARBG.txt",
+		"Movie.2010.1080p/movie.sfv",
+		"Movie.2010.1080p/Sample/movie-sample.mkv",
+	} {
+		path := filepath.Join(downloads, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("unable to create dir: %s", err.Error())
+		}
+		writeFile(t, path)
+	}
+
+	filer, err := New(downloads)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	rules := DefaultRules()
+	rules[SidecarNFO] = ActionRename
+	rules[SidecarArtwork] = ActionRename
+	rules[SidecarText] = ActionDelete
+	rules[SidecarSample] = ActionMove
+	filer.AttachSidecars(rules)
+
+	//samples aren't matched themselves
+	var names []string
+	for _, files := range filer.GetFiles() {
+		for _, file := range files {
+			names = append(names, file.GetName())
+			file.NewName = "Movie (2010)"
+			file.NewDir = filepath.Join(library, "Movie (2010)")
+		}
+	}
+	if diff := pretty.Compare([]string{"Movie.2010.1080p.mkv"}, names); diff != "" {
+		t.Errorf("GetFiles unexpected diff (-want +got):\n%s", diff)
+	}
+
+	run := filer.RenameBatch()
+
+	expected := []string{
+		"downloads/Movie.2010.1080p/movie.sfv",
+		"library/Movie (2010)/Movie (2010)-poster.jpg",
+		"library/Movie (2010)/Movie (2010).mkv",
+		"library/Movie (2010)/Movie (2010).nfo",
+		"library/Movie (2010)/Sample/movie-sample.mkv",
+	}
+	if diff := pretty.Compare(expected, listFiles(t, root)); diff != "" {
+		t.Errorf("RenameBatch unexpected diff (-want +got):\n%s", diff)
+	}
+
+	//deleted files are recorded, so undo can list them as not restorable
+	deleted := []string{filepath.Join(downloads, "Movie.2010.1080p", "RARBG.txt")}
+	if diff := pretty.Compare(deleted, run.Deleted); diff != "" {
+		t.Errorf("RenameBatch deleted unexpected diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,118 @@
+package filing
+
+import (
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+var (
+	//folders subtitles are kept in by releases, beside the media files
+	subtitleDirs = map[string]struct{}{
+		"subs":      {},
+		"subtitles": {},
+	}
+
+	//languages maps the language names and ISO 639 codes found in subtitle
+	//file names to the ISO 639-1 codes used in their new names
+	languages = map[string]string{
+		"en": "en", "eng": "en", "english": "en",
+		"fr": "fr", "fre": "fr", "fra": "fr", "french": "fr",
+		"de": "de", "ger": "de", "deu": "de", "german": "de",
+		"es": "es", "spa": "es", "spanish": "es",
+		"it": "it", "ita": "it", "italian": "it",
+		"pt": "pt", "por": "pt", "portuguese": "pt",
+		"nl": "nl", "dut": "nl", "nld": "nl", "dutch": "nl",
+		"sv": "sv", "swe": "sv", "swedish": "sv",
+		"da": "da", "dan": "da", "danish": "da",
+		"no": "no", "nor": "no", "norwegian": "no",
+		"fi": "fi", "fin": "fi", "finnish": "fi",
+		"pl": "pl", "pol": "pl", "polish": "pl",
+		"cs": "cs", "cze": "cs", "ces": "cs", "czech": "cs",
+		"hu": "hu", "hun": "hu", "hungarian": "hu",
+		"ro": "ro", "rum": "ro", "ron": "ro", "romanian": "ro",
+		"el": "el", "gre": "el", "ell": "el", "greek": "el",
+		"tr": "tr", "tur": "tr", "turkish": "tr",
+		"ru": "ru", "rus": "ru", "russian": "ru",
+		"ar": "ar", "ara": "ar", "arabic": "ar",
+		"he": "he", "heb": "he", "hebrew": "he",
+		"hin": "hi", "hindi": "hi", //"hi" is taken to mean hearing impaired
+		"ja": "ja", "jpn": "ja", "japanese": "ja",
+		"ko": "ko", "kor": "ko", "korean": "ko",
+		"zh": "zh", "chi": "zh", "zho": "zh", "chinese": "zh",
+	}
+
+	//tags marking subtitles for the deaf and hard of hearing
+	sdhTags = map[string]struct{}{
+		"sdh": {},
+		"hi":  {},
+		"cc":  {},
+	}
+
+	//tags marking subtitles only for foreign dialogue
+	forcedTags = map[string]struct{}{
+		"forced":  {},
+		"foreign": {},
+	}
+)
+
+//attaches each subtitle file to the media file it belongs to as a companion,
+//renamed after it
+func (f *Filer) attachSubtitles(files []string) {
+
+	for _, path := range files {
+		ext := filepath.Ext(path)
+		if _, ok := supportedSubtitle[strings.ToLower(ext)]; !ok {
+			continue
+		}
+
+		file, tags := f.companionOf(path, subtitleDirs)
+		if file == nil {
+			continue
+		}
+
+		file.Companions = append(file.Companions, &Companion{
+			Path:   path,
+			Action: ActionRename,
+			Suffix: subtitleSuffix(tags),
+			Ext:    ext,
+		})
+	}
+
+	f.numberCompanions()
+}
+
+//returns the Plex style suffix for the tags found in a subtitle name, e.g.
+//".en.sdh" or ".en.forced"
+func subtitleSuffix(tags string) string {
+
+	var language string
+	var sdh, forced bool
+
+	words := strings.FieldsFunc(strings.ToLower(tags), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for _, word := range words {
+		if code, ok := languages[word]; ok && language == "" {
+			language = code
+		} else if _, ok := sdhTags[word]; ok {
+			sdh = true
+		} else if _, ok := forcedTags[word]; ok {
+			forced = true
+		}
+	}
+
+	suffix := ""
+	if language != "" {
+		suffix += "." + language
+	}
+	if sdh {
+		suffix += ".sdh"
+	}
+	if forced {
+		suffix += ".forced"
+	}
+
+	return suffix
+}
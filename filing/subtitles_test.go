@@ -0,0 +1,96 @@
+package filing
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestSubtitleSuffix(t *testing.T) {
+
+	var tests = []struct {
+		tags     string
+		expected string
+	}{
+		{tags: "", expected: ""},
+		{tags: ".en", expected: ".en"},
+		{tags: ".eng.forced", expected: ".en.forced"},
+		{tags: "2_English", expected: ".en"},
+		{tags: "3_English.SDH", expected: ".en.sdh"},
+		{tags: ".English (Forced)", expected: ".en.forced"},
+		{tags: ".fre.HI", expected: ".fr.sdh"},
+		{tags: ".Hindi", expected: ".hi"},
+		{tags: ".unknown", expected: ""},
+	}
+
+	for _, test := range tests {
+		if actual := subtitleSuffix(test.tags); actual != test.expected {
+			t.Errorf("subtitleSuffix(%q) expected %q, got %q", test.tags, test.expected, actual)
+		}
+	}
+}
+
+func TestFiler_Subtitles(t *testing.T) {
+
+	root, err := ioutil.TempDir("", "media-mapper-subtitles")
+	if err != nil {
+		t.Fatalf("unable to create dir: %s", err.Error())
+	}
+	defer os.RemoveAll(root)
+
+	for _, name := range []string{
+		"Movie.2010.1080p/Movie.2010.1080p.mkv",
+		"Movie.2010.1080p/Subs/English.srt",
+		"Movie.2010.1080p/Subs/English.Forced.srt",
+		"Show.S01/Show.S01E01.mkv",
+		"Show.S01/Show.S01E01.eng.srt",
+		"Show.S01/Show.S01E02.mkv",
+		"Show.S01/Subs/Show.S01E02/2_English.srt",
+		"Show.S01/Subs/Show.S01E02/3_English.srt",
+		"Show.S01/Subs/Show.S01E02/4_French.srt",
+		"Show.S01/Subs/Other.srt", //several media files, so it's unclear which it belongs to
+	} {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("unable to create dir: %s", err.Error())
+		}
+		writeFile(t, path)
+	}
+
+	filer, err := New(root)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	newNames := map[string]string{
+		"Movie.2010.1080p": "Movie (2010)",
+		"Show.S01E01":      "Show - S01E01",
+		"Show.S01E02":      "Show - S01E02",
+	}
+	for _, files := range filer.GetFiles() {
+		for _, file := range files {
+			file.NewName = newNames[file.Name]
+		}
+	}
+
+	filer.RenameBatch()
+
+	expected := []string{
+		"Movie.2010.1080p/Movie (2010).en.forced.srt",
+		"Movie.2010.1080p/Movie (2010).en.srt",
+		"Movie.2010.1080p/Movie (2010).mkv",
+		"Show.S01/Show - S01E01.en.srt",
+		"Show.S01/Show - S01E01.mkv",
+		"Show.S01/Show - S01E02.2.en.srt",
+		"Show.S01/Show - S01E02.en.srt",
+		"Show.S01/Show - S01E02.fr.srt",
+		"Show.S01/Show - S01E02.mkv",
+		"Show.S01/Subs/Other.srt",
+	}
+	if diff := pretty.Compare(expected, listFiles(t, root)); diff != "" {
+		t.Errorf("RenameBatch unexpected diff (-want +got):\n%s", diff)
+	}
+}
@@ -1,6 +1,10 @@
 package filing
 
-import "fmt"
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
 
 var (
 	//supported supportedVideo file types
@@ -25,9 +29,22 @@ var (
 )
 
 type File struct {
-	Name    string //file name without extension
-	NewName string //
-	Ext     string //file extension
+	Name      string //file name without extension
+	NewName   string //
+	NewDir    string //directory to move the file to, empty to rename in place
+	Ext       string //file extension
+	Overwrite bool   //replace an existing file at the new path
+
+	Companions []*Companion //files renamed along with this one, such as subtitles
+}
+
+//Companion is a file belonging to a media file, such as its subtitles, that
+//is renamed, moved or deleted along with it
+type Companion struct {
+	Path   string //current path
+	Action Action
+	Suffix string //added to the media file's new name when renamed, e.g. ".en.forced"
+	Ext    string
 }
 
 func (f *File) GetName() string {
@@ -37,3 +54,39 @@ func (f *File) GetName() string {
 func (f *File) GetNewName() string {
 	return fmt.Sprintf("%s%s", f.NewName, f.Ext)
 }
+
+//GetNewPath returns the path the file in dir will be renamed to
+func (f *File) GetNewPath(dir string) string {
+
+	if f.NewDir != "" {
+		dir = f.NewDir
+	}
+
+	return filepath.Join(dir, f.GetNewName())
+}
+
+//IsMoved returns true if the file in dir will be moved to another directory
+func (f *File) IsMoved(dir string) bool {
+	return f.NewDir != "" && filepath.Clean(f.NewDir) != filepath.Clean(dir)
+}
+
+//GetNewPath returns the path the companion of file in dir will be renamed
+//(or moved) to, alongside the file. Companions that are deleted or left
+//alone keep their path.
+func (c *Companion) GetNewPath(dir string, file *File) string {
+
+	newDir := filepath.Dir(file.GetNewPath(dir))
+
+	switch c.Action {
+	case ActionRename:
+		return filepath.Join(newDir, file.NewName+c.Suffix+c.Ext)
+	case ActionMove:
+		//keeps its place relative to the file, e.g. in a Sample folder
+		if rel, err := filepath.Rel(dir, c.Path); err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.Join(newDir, rel)
+		}
+		return filepath.Join(newDir, filepath.Base(c.Path))
+	}
+
+	return c.Path
+}
@@ -3,10 +3,11 @@ module github.com/rustedturnip/media-mapper
 go 1.15
 
 require (
-	github.com/agnivade/levenshtein v1.1.0 // indirect
+	github.com/agnivade/levenshtein v1.1.0
 	github.com/fatih/color v1.9.0
 	github.com/kylelemons/godebug v1.1.0
 	github.com/middelink/go-parse-torrent-name v0.0.0-20190301154245-3ff4efacd4c4
 	github.com/r3labs/diff/v2 v2.8.0 // indirect
 	golang.org/x/net v0.0.0-20190603091049-60506f45cf65 // indirect
+	golang.org/x/text v0.3.6
 )
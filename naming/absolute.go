@@ -0,0 +1,77 @@
+package naming
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	//release group and other tags in brackets, e.g. [HorribleSubs]
+	bracketTag = regexp.MustCompile(`^\s*\[[^\]]*\]\s*`)
+
+	//the title followed by the episode's absolute number, either after a
+	//dash, an episode prefix or a space, e.g. "Show - 137 [1080p]",
+	//"Show Episode 12" or "Show 137v2". The last is only used for releases
+	//tagged by a group, as titles of movies often end in numbers, e.g.
+	//Apollo 13, and none are used for numbers which can be a year, e.g.
+	//"Rocky - 1976".
+	absoluteEpisode = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)^(.+?)\s+-\s+(?:(?:ep|episode)\.?\s*)?(\d{1,4})(?:v\d)?(?:\s*[\[(]|\s+-\s|\s*$)`),
+		regexp.MustCompile(`(?i)^(.+?)\s+(?:ep|episode)\.?\s*(\d{1,4})(?:v\d)?(?:\s*[\[(]|\s+-\s|\s*$)`),
+		regexp.MustCompile(`(?i)^(.+?)\s+(\d{1,4})(?:v\d)?(?:\s*[\[(]|\s+-\s|\s*$)`),
+	}
+
+	//a year in brackets after the number, which makes it part of a movie's
+	//title, e.g. "Toy Story 3 (2010)" or "Star Wars - Episode 4 (1977)"
+	movieYear = regexp.MustCompile(`^(?:v\d)?\s*\(\s*(?:19|20)\d{2}\s*\)`)
+
+	//seasons, which episodes numbered absolutely don't have, e.g. Season 2 or S02
+	seasonTag = regexp.MustCompile(`(?i)(?:^|[^a-z0-9])(?:season\s*\d|s\d{1,3}(?:[^0-9]|$))`)
+)
+
+//ParseAbsolute returns the title and number of an episode numbered from the
+//show's first episode rather than within its season, as anime usually is,
+//e.g. "[Group] Show - 137 [1080p]". The title is empty and the number 0 if
+//the name isn't numbered like that, or the number is followed by a year, as
+//in "Toy Story 3 (2010)".
+func ParseAbsolute(name string) (string, int) {
+
+	if seasonTag.MatchString(name) || firstEpisode.MatchString(name) {
+		return "", 0
+	}
+
+	tagged := bracketTag.MatchString(name)
+	name = bracketTag.ReplaceAllString(name, "")
+
+	for i, re := range absoluteEpisode {
+		spaced := i == len(absoluteEpisode)-1
+		if spaced && !tagged {
+			continue
+		}
+
+		loc := re.FindStringSubmatchIndex(name)
+		if loc == nil || movieYear.MatchString(name[loc[5]:]) {
+			continue
+		}
+
+		number, _ := strconv.Atoi(name[loc[4]:loc[5]])
+		if number == 0 || isYear(name[loc[4]:loc[5]]) {
+			continue
+		}
+
+		title := strings.TrimSpace(strings.TrimRight(name[loc[2]:loc[3]], " -"))
+		if title == "" {
+			continue
+		}
+
+		return title, number
+	}
+
+	return "", 0
+}
+
+//reports whether a number is likely a year, e.g. 2019
+func isYear(number string) bool {
+	return len(number) == 4 && (strings.HasPrefix(number, "19") || strings.HasPrefix(number, "20"))
+}
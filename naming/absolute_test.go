@@ -0,0 +1,98 @@
+package naming
+
+import (
+	"testing"
+)
+
+func TestParseAbsolute(t *testing.T) {
+
+	var tests = []struct {
+		name   string
+		input  string
+		title  string
+		number int
+	}{
+		{
+			name:   "Group, Dash and Resolution",
+			input:  "[HorribleSubs] Boruto - Naruto Next Generations - 137 [1080p]",
+			title:  "Boruto - Naruto Next Generations",
+			number: 137,
+		},
+		{
+			name:   "Four Digit Number",
+			input:  "[SubsPlease] One Piece - 1071 (1080p) [ABCD1234]",
+			title:  "One Piece",
+			number: 1071,
+		},
+		{
+			name:   "Version",
+			input:  "Show - 01v2 [720p]",
+			title:  "Show",
+			number: 1,
+		},
+		{
+			name:   "Space Separated",
+			input:  "[Group] Show 137 [1080p]",
+			title:  "Show",
+			number: 137,
+		},
+		{
+			name:   "Episode Prefix",
+			input:  "Show - Episode 12",
+			title:  "Show",
+			number: 12,
+		},
+		{
+			name:  "Year Not Episode",
+			input: "[Group] Blade Runner 2049",
+		},
+		{
+			name:  "Movie Ending in Number",
+			input: "Apollo 13 (1995) [1080p]",
+		},
+		{
+			name:  "Season and Episode",
+			input: "Show - S01E03 - Title",
+		},
+		{
+			name:  "Season x Episode",
+			input: "Show - 1x03 - Title",
+		},
+		{
+			name:  "Season Without Episode",
+			input: "[Group] Show Season 2 - 05",
+		},
+		{
+			name:  "Movie",
+			input: "The.Matrix.1999.1080p",
+		},
+		{
+			name:  "Dashed Year",
+			input: "Rocky - 1976",
+		},
+		{
+			name:  "Dashed Year of Movie Ending in Number",
+			input: "Blade Runner - 2049",
+		},
+		{
+			name:  "Dashed Year with Apostrophe",
+			input: "Ocean's Eleven - 2001",
+		},
+		{
+			name:  "Tagged Movie Sequel",
+			input: "[YTS] Toy Story 3 (2010) [1080p]",
+		},
+		{
+			name:  "Movie Episode",
+			input: "Star Wars - Episode 4 (1977)",
+		},
+	}
+
+	for _, test := range tests {
+		title, number := ParseAbsolute(test.input)
+
+		if title != test.title || number != test.number {
+			t.Errorf("%s expected %q %d, got %q %d", test.name, test.title, test.number, title, number)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package naming
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	dateTitleTrim = " ._-([" //separators between the title and the date, e.g. "Show - (2020.10.23)"
+)
+
+var (
+	//the air date of an episode of a daily show, e.g. 2020.10.23 or 2020-10-23
+	airDate = regexp.MustCompile(`(?:^|[^0-9])((?:19|20)\d{2})[. _-](\d{2})[. _-](\d{2})(?:[^0-9]|$)`)
+)
+
+//ParseDate returns the title and air date of an episode named by the date it
+//aired rather than its season and episode, as daily shows are, e.g.
+//"The.Daily.Show.2020.10.23.720p". The title is empty and the date zero if
+//the name isn't dated, or also has a season and episode.
+func ParseDate(name string) (string, time.Time) {
+
+	if firstEpisode.MatchString(name) {
+		return "", time.Time{}
+	}
+
+	loc := airDate.FindStringSubmatchIndex(name)
+	if loc == nil {
+		return "", time.Time{}
+	}
+
+	year, _ := strconv.Atoi(name[loc[2]:loc[3]])
+	month, _ := strconv.Atoi(name[loc[4]:loc[5]])
+	day, _ := strconv.Atoi(name[loc[6]:loc[7]])
+
+	//dates which don't exist, e.g. 2020.13.01, are normalised by time.Date
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if date.Month() != time.Month(month) || date.Day() != day {
+		return "", time.Time{}
+	}
+
+	title := strings.NewReplacer(".", " ", "_", " ").Replace(name[:loc[2]])
+	title = strings.TrimSpace(strings.TrimRight(title, dateTitleTrim))
+	if title == "" {
+		return "", time.Time{}
+	}
+
+	return title, date
+}
@@ -0,0 +1,59 @@
+package naming
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+
+	var tests = []struct {
+		name  string
+		input string
+		title string
+		date  time.Time
+	}{
+		{
+			name:  "Dotted",
+			input: "The.Daily.Show.2020.10.23.720p.WEB.h264",
+			title: "The Daily Show",
+			date:  time.Date(2020, 10, 23, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "Dashed",
+			input: "Last Week Tonight with John Oliver - 2021-02-14 - Vaccines",
+			title: "Last Week Tonight with John Oliver",
+			date:  time.Date(2021, 2, 14, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "Bracketed",
+			input: "Newsnight (1999.01.05)",
+			title: "Newsnight",
+			date:  time.Date(1999, 1, 5, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "Invalid Date",
+			input: "Show.2020.13.01",
+		},
+		{
+			name:  "Season and Episode",
+			input: "Show.S01E01.2020.10.23",
+		},
+		{
+			name:  "Year Only",
+			input: "The.Matrix.1999.1080p",
+		},
+		{
+			name:  "No Title",
+			input: "2020.10.23.720p",
+		},
+	}
+
+	for _, test := range tests {
+		title, date := ParseDate(test.input)
+
+		if title != test.title || !date.Equal(test.date) {
+			t.Errorf("%s expected %q %s, got %q %s", test.name, test.title, test.date, title, date)
+		}
+	}
+}
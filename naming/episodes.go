@@ -0,0 +1,103 @@
+package naming
+
+import (
+	"regexp"
+	"strconv"
+)
+
+const (
+	maxEpisodes = 20 //most episodes in a multi-episode file, larger ranges are likely misparsed
+)
+
+var (
+	//the first episode of a file, e.g. S01E01 or 1x01
+	firstEpisode = regexp.MustCompile(`(?i)(?:^|[^a-z0-9])(?:s(\d{1,3})e(\d{1,4})|(\d{1,3})x(\d{1,4}))`)
+
+	//episodes following the first (or another following episode), e.g. E02,
+	//-E03, .E02, -S01E03, -1x02, x02 (only after 1x01) or -03. A - makes a
+	//range from the previous episode.
+	nextEpisode = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)^([-. ]?)(?:s(\d{1,3}))?e(\d{1,4})`),
+		regexp.MustCompile(`(?i)^([-. ]?)(\d{1,3})x(\d{1,4})`),
+		regexp.MustCompile(`(?i)^(-)()(\d{1,4})`),
+	}
+	nextXEpisode = regexp.MustCompile(`(?i)^()()x(\d{1,4})`)
+)
+
+//ParseEpisodes returns the season and episodes of a multi-episode file from
+//its name, e.g. "Show.S01E01E02" or "Show.S01E01-E03", or 0 and nil if it
+//isn't one. Episodes are returned in order, ranges including every episode
+//in them.
+func ParseEpisodes(name string) (int, []int) {
+
+	loc := firstEpisode.FindStringSubmatchIndex(name)
+	if loc == nil {
+		return 0, nil
+	}
+
+	match := func(i int) string {
+		if loc[2*i] < 0 {
+			return ""
+		}
+		return name[loc[2*i]:loc[2*i+1]]
+	}
+
+	next := nextEpisode
+	season, first := match(1), match(2)
+	if season == "" {
+		season, first = match(3), match(4)
+		next = append([]*regexp.Regexp{nextXEpisode}, nextEpisode...)
+	}
+
+	seasonNum, _ := strconv.Atoi(season)
+	episode, _ := strconv.Atoi(first)
+	episodes := []int{episode}
+
+	for rest := name[loc[1]:]; ; {
+		var found []string
+		for _, re := range next {
+			if found = re.FindStringSubmatch(rest); found != nil {
+				break
+			}
+		}
+		if found == nil {
+			break
+		}
+		rest = rest[len(found[0]):]
+
+		//numbers run on, or are a resolution like 720p, rather than an episode
+		if rest != "" && (isDigit(rest[0]) || rest[0] == 'p' || rest[0] == 'P') {
+			break
+		}
+
+		//following episodes must be of the same season, and after the last
+		if found[2] != "" {
+			if s, _ := strconv.Atoi(found[2]); s != seasonNum {
+				break
+			}
+		}
+
+		number, _ := strconv.Atoi(found[3])
+		last := episodes[len(episodes)-1]
+		if number <= last || number-episodes[0] >= maxEpisodes {
+			break
+		}
+
+		if found[1] == "-" {
+			for n := last + 1; n < number; n++ {
+				episodes = append(episodes, n)
+			}
+		}
+		episodes = append(episodes, number)
+	}
+
+	if len(episodes) < 2 {
+		return 0, nil
+	}
+
+	return seasonNum, episodes
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,92 @@
+package naming
+
+import (
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestParseEpisodes(t *testing.T) {
+
+	var tests = []struct {
+		name     string
+		input    string
+		season   int
+		expected []int
+	}{
+		{
+			name:     "Consecutive Episodes",
+			input:    "Show.S01E01E02.720p",
+			season:   1,
+			expected: []int{1, 2},
+		},
+		{
+			name:     "Episode Range",
+			input:    "Show.S01E01-E03",
+			season:   1,
+			expected: []int{1, 2, 3},
+		},
+		{
+			name:     "Bare Episode Range",
+			input:    "Show.S02E05-07.1080p",
+			season:   2,
+			expected: []int{5, 6, 7},
+		},
+		{
+			name:     "Range With Season",
+			input:    "Show - S01E01-S01E02 - Title A + Title B",
+			season:   1,
+			expected: []int{1, 2},
+		},
+		{
+			name:     "Separated Episodes",
+			input:    "Show.S01E01.E02.HDTV",
+			season:   1,
+			expected: []int{1, 2},
+		},
+		{
+			name:     "Season x Episode",
+			input:    "Show 3x01x02",
+			season:   3,
+			expected: []int{1, 2},
+		},
+		{
+			name:     "Season x Episode Range",
+			input:    "Show.1x01-1x03.mkv",
+			season:   1,
+			expected: []int{1, 2, 3},
+		},
+		{
+			name:  "Single Episode",
+			input: "Show.S01E01.x264",
+		},
+		{
+			name:  "Single Episode With Resolution",
+			input: "Show.S01E01-720p",
+		},
+		{
+			name:  "Different Season",
+			input: "Show.S01E01-S02E01",
+		},
+		{
+			name:  "Decreasing Episodes",
+			input: "Show.S01E03E02",
+		},
+		{
+			name:  "No Episodes",
+			input: "The Matrix (1999)",
+		},
+	}
+
+	for _, test := range tests {
+		season, episodes := ParseEpisodes(test.input)
+
+		if season != test.season {
+			t.Errorf("%s expected season %d, got %d", test.name, test.season, season)
+		}
+
+		if diff := pretty.Compare(test.expected, episodes); diff != "" {
+			t.Errorf("%s unexpected diff (-want +got):\n%s", test.name, diff)
+		}
+	}
+}
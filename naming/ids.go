@@ -0,0 +1,53 @@
+package naming
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/rustedturnip/media-mapper/types"
+)
+
+var (
+	//tags Plex and Jellyfin use to match media with a database, e.g.
+	//{tmdb-603}, [tvdbid-79126] or [imdbid=tt0133093]
+	idTag = regexp.MustCompile(`(?i)[{\[](tmdb|tvdb|imdb)(?:id)?[-=]([^}\]\s]+)[}\]]`)
+
+	//IMDb IDs not in a tag, e.g. The.Matrix.tt0133093.mkv
+	imdbID = regexp.MustCompile(`(?i)(?:^|[^a-z0-9])(tt\d{7,8})(?:[^0-9]|$)`)
+)
+
+//ParseIDs returns the IDs tagged in a file or directory name, such as those
+//added by PlexID and JellyfinID along with bare IMDb IDs. IDs which aren't
+//valid for their database are ignored.
+func ParseIDs(name string) types.ExternalIDs {
+
+	var ids types.ExternalIDs
+
+	for _, match := range idTag.FindAllStringSubmatch(name, -1) {
+		value := match[2]
+
+		switch strings.ToLower(match[1]) {
+		case "tmdb":
+			if id, err := strconv.Atoi(value); err == nil && id > 0 && ids.TMDB == 0 {
+				ids.TMDB = id
+			}
+		case "tvdb":
+			if id, err := strconv.Atoi(value); err == nil && id > 0 && ids.TVDB == 0 {
+				ids.TVDB = id
+			}
+		case "imdb":
+			if imdbID.MatchString(value) && ids.IMDB == "" {
+				ids.IMDB = strings.ToLower(value)
+			}
+		}
+	}
+
+	if ids.IMDB == "" {
+		if match := imdbID.FindStringSubmatch(name); match != nil {
+			ids.IMDB = strings.ToLower(match[1])
+		}
+	}
+
+	return ids
+}
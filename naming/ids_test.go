@@ -0,0 +1,54 @@
+package naming
+
+import (
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/rustedturnip/media-mapper/types"
+)
+
+func TestParseIDs(t *testing.T) {
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected types.ExternalIDs
+	}{
+		{
+			name:     "Plex Tag",
+			input:    "The Matrix (1999) {tmdb-603}",
+			expected: types.ExternalIDs{TMDB: 603},
+		},
+		{
+			name:     "Jellyfin Tags",
+			input:    "The Wire [tvdbid-79126] [imdbid=tt0306414]",
+			expected: types.ExternalIDs{TVDB: 79126, IMDB: "tt0306414"},
+		},
+		{
+			name:     "Bare IMDb ID",
+			input:    "The.Matrix.1999.tt0133093.1080p",
+			expected: types.ExternalIDs{IMDB: "tt0133093"},
+		},
+		{
+			name:     "First Tag Used",
+			input:    "{TMDB-603} {tmdb-604}",
+			expected: types.ExternalIDs{TMDB: 603},
+		},
+		{
+			name:     "Invalid IDs Ignored",
+			input:    "Movie {tmdb-abc} {imdb-603} stt01330930",
+			expected: types.ExternalIDs{},
+		},
+		{
+			name:     "No IDs",
+			input:    "The Matrix (1999)",
+			expected: types.ExternalIDs{},
+		},
+	}
+
+	for _, test := range tests {
+		if diff := pretty.Compare(test.expected, ParseIDs(test.input)); diff != "" {
+			t.Errorf("%s unexpected diff (-want +got):\n%s", test.name, diff)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package naming
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+const (
+	DefaultScheme = "default"
+
+	//directories used when organising, following Plex and Jellyfin's layout
+	DefaultMovieDir = "{{.Title}} ({{.Year}})"
+	DefaultTVDir    = "{{.Title}}/{{if .Season}}Season {{pad 2 .Season}}{{else}}Specials{{end}}"
+
+	//episodes named like Plex and Jellyfin expect, e.g. Show - S01E01-E02 - Title
+	plexTV = "{{.Title}} - S{{pad 2 .Season}}E{{pad 2 .Episode}}{{with .LastEpisode}}-E{{pad 2 .}}{{end}} - {{.EpisodeTitle}}"
+)
+
+//Fields are the values available to naming templates, e.g.
+//{{.Title}} - S{{pad 2 .Season}}E{{pad 2 .Episode}} - {{.EpisodeTitle}}
+//along with the PlexID and JellyfinID tags, e.g. {{.Title}} {{.PlexID}}
+type Fields struct {
+	Title           string //movie or show title
+	Year            int    //movie release or show first aired year, 0 if unknown
+	Season          int
+	Episode         int    //first episode of a multi-episode file
+	AbsoluteEpisode int    //episode's number counting from the show's first, 0 if unknown
+	AirDate         string //episode's air date, e.g. 2020-10-23, empty if unknown
+	EpisodeTitle    string //titles of a multi-episode file's episodes, joined with " + "
+	Provider        string //database the match was found in, e.g. TMDB
+	ID              int    //ID of the movie or show in the provider's database
+
+	//multi-episode files, e.g. S01E01E02, also have the last of their episodes
+	//(0 otherwise) and every episode's number and title
+	LastEpisode   int
+	Episodes      []int
+	EpisodeTitles []string
+
+	//IDs of the movie or show in each database, including the provider's,
+	//0 or empty if unknown
+	TMDB int
+	TVDB int
+	IMDB string
+
+	//quality tags parsed from the original file name, empty if not present
+	Resolution string
+	Quality    string
+	Codec      string
+	Audio      string
+	Group      string
+}
+
+//Templates are the text of a scheme's templates. The directory templates,
+//used when organising files, are optional and may contain / to create
+//nested directories.
+type Templates struct {
+	Movie    string `json:"movie"`
+	TV       string `json:"tv"`
+	MovieDir string `json:"movieDir"` //defaults to DefaultMovieDir
+	TVDir    string `json:"tvDir"`    //defaults to DefaultTVDir
+}
+
+//Scheme is a set of templates used to name movies and tv episodes, and the
+//directories they're organised into
+type Scheme struct {
+	Name     string
+	movie    *template.Template
+	tv       *template.Template
+	movieDir *template.Template
+	tvDir    *template.Template
+}
+
+//Schemes are the built in naming schemes, which can be selected by name
+//alongside those defined in config
+var Schemes = map[string]*Scheme{
+	DefaultScheme: mustScheme(DefaultScheme, &Templates{
+		Movie: "{{.Title}} ({{.Year}})",
+		TV:    "{{.Title}} - {{.Season}}x{{.Episode}}{{with .LastEpisode}}-{{.}}{{end}} - {{.EpisodeTitle}}",
+	}),
+	"plex": mustScheme("plex", &Templates{
+		Movie: "{{.Title}} ({{.Year}})",
+		TV:    plexTV,
+	}),
+	"plex-ids": mustScheme("plex-ids", &Templates{
+		Movie:    "{{.Title}} ({{.Year}}){{with .PlexID}} {{.}}{{end}}",
+		TV:       plexTV,
+		MovieDir: "{{.Title}} ({{.Year}}){{with .PlexID}} {{.}}{{end}}",
+		TVDir:    "{{.Title}}{{with .PlexID}} {{.}}{{end}}/{{if .Season}}Season {{pad 2 .Season}}{{else}}Specials{{end}}",
+	}),
+	"jellyfin-ids": mustScheme("jellyfin-ids", &Templates{
+		Movie:    "{{.Title}} ({{.Year}}){{with .JellyfinID}} {{.}}{{end}}",
+		TV:       plexTV,
+		MovieDir: "{{.Title}} ({{.Year}}){{with .JellyfinID}} {{.}}{{end}}",
+		TVDir:    "{{.Title}}{{with .JellyfinID}} {{.}}{{end}}/{{if .Season}}Season {{pad 2 .Season}}{{else}}Specials{{end}}",
+	}),
+}
+
+var funcs = template.FuncMap{
+	"pad":  pad,
+	"join": strings.Join,
+}
+
+//NewScheme parses the templates of a scheme. The templates are executed
+//once with empty fields so that unknown fields and functions are reported
+//now rather than part way through a run.
+func NewScheme(name string, templates *Templates) (*Scheme, error) {
+
+	movieDir := templates.MovieDir
+	if movieDir == "" {
+		movieDir = DefaultMovieDir
+	}
+
+	tvDir := templates.TVDir
+	if tvDir == "" {
+		tvDir = DefaultTVDir
+	}
+
+	scheme := &Scheme{
+		Name: name,
+	}
+
+	var err error
+	if scheme.movie, err = parse(name+" movie", templates.Movie); err != nil {
+		return nil, err
+	}
+	if scheme.tv, err = parse(name+" tv", templates.TV); err != nil {
+		return nil, err
+	}
+	if scheme.movieDir, err = parse(name+" movie dir", movieDir); err != nil {
+		return nil, err
+	}
+	if scheme.tvDir, err = parse(name+" tv dir", tvDir); err != nil {
+		return nil, err
+	}
+
+	return scheme, nil
+}
+
+func mustScheme(name string, templates *Templates) *Scheme {
+
+	scheme, err := NewScheme(name, templates)
+	if err != nil {
+		panic(err)
+	}
+
+	return scheme
+}
+
+func parse(name, text string) (*template.Template, error) {
+
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("naming scheme %s: empty template", name)
+	}
+
+	tmpl, err := template.New(name).Funcs(funcs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("naming scheme %s: %s", name, err.Error())
+	}
+
+	if _, err := execute(tmpl, &Fields{}); err != nil {
+		return nil, fmt.Errorf("naming scheme %s: %s", name, err.Error())
+	}
+
+	return tmpl, nil
+}
+
+//Movie returns the name of a movie file (without extension)
+func (s *Scheme) Movie(fields *Fields) (string, error) {
+	return execute(s.movie, fields)
+}
+
+//TV returns the name of an episode file (without extension)
+func (s *Scheme) TV(fields *Fields) (string, error) {
+	return execute(s.tv, fields)
+}
+
+//MovieDir returns the directory a movie file is organised into, relative to
+//the library
+func (s *Scheme) MovieDir(fields *Fields) (string, error) {
+	return executeDir(s.movieDir, fields)
+}
+
+//TVDir returns the directory an episode file is organised into, relative to
+//the library
+func (s *Scheme) TVDir(fields *Fields) (string, error) {
+	return executeDir(s.tvDir, fields)
+}
+
+//executes a directory template, ensuring the directory stays within the library
+func executeDir(tmpl *template.Template, fields *Fields) (string, error) {
+
+	dir, err := execute(tmpl, fields)
+	if err != nil {
+		return "", err
+	}
+
+	dir = filepath.Clean(filepath.FromSlash(dir))
+	if filepath.IsAbs(dir) || dir == "." || dir == ".." || strings.HasPrefix(dir, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid directory %q", dir)
+	}
+
+	return dir, nil
+}
+
+func execute(tmpl *template.Template, fields *Fields) (string, error) {
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, fields); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(buf.String()), nil
+}
+
+//PlexID returns the tag Plex uses to match the movie or show with a
+//database, e.g. {tmdb-27205}, or an empty string if it has no IDs
+func (f *Fields) PlexID() string {
+
+	if db, id := f.tagID(); db != "" {
+		return fmt.Sprintf("{%s-%s}", db, id)
+	}
+
+	return ""
+}
+
+//JellyfinID returns the tag Jellyfin uses to match the movie or show with a
+//database, e.g. [tmdbid-27205], or an empty string if it has no IDs
+func (f *Fields) JellyfinID() string {
+
+	if db, id := f.tagID(); db != "" {
+		return fmt.Sprintf("[%sid-%s]", db, id)
+	}
+
+	return ""
+}
+
+//returns the database (in lower case) and ID of the movie or show to tag it
+//with, preferring the provider's ID and then TMDB, TVDB and IMDb's
+func (f *Fields) tagID() (string, string) {
+
+	switch {
+	case strings.EqualFold(f.Provider, "TVDB") && f.TVDB != 0:
+		return "tvdb", strconv.Itoa(f.TVDB)
+	case f.TMDB != 0:
+		return "tmdb", strconv.Itoa(f.TMDB)
+	case f.TVDB != 0:
+		return "tvdb", strconv.Itoa(f.TVDB)
+	case f.IMDB != "":
+		return "imdb", f.IMDB
+	}
+
+	return "", ""
+}
+
+//zero pads n to width digits, e.g. pad 2 3 is 03
+func pad(width, n int) string {
+	return fmt.Sprintf("%0*d", width, n)
+}
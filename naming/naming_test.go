@@ -0,0 +1,289 @@
+package naming
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestScheme(t *testing.T) {
+
+	episode := &Fields{
+		Title:        "Broadchurch",
+		Year:         2013,
+		Season:       1,
+		Episode:      3,
+		EpisodeTitle: "Episode 3",
+		Provider:     "TMDB",
+		ID:           1427,
+		TMDB:         1427,
+		TVDB:         264204,
+		Resolution:   "720p",
+	}
+
+	multiEpisode := &Fields{
+		Title:         "Broadchurch",
+		Season:        1,
+		Episode:       1,
+		EpisodeTitle:  "Episode 1 + Episode 2",
+		LastEpisode:   2,
+		Episodes:      []int{1, 2},
+		EpisodeTitles: []string{"Episode 1", "Episode 2"},
+	}
+
+	movie := &Fields{
+		Title:    "Inception",
+		Year:     2010,
+		Provider: "TMDB",
+		ID:       27205,
+		TMDB:     27205,
+		IMDB:     "tt1375666",
+		Quality:  "BluRay",
+	}
+
+	var tests = []struct {
+		name     string
+		scheme   *Scheme
+		fields   *Fields
+		movie    bool
+		expected string
+	}{
+		{
+			name:     "Default TV",
+			scheme:   Schemes[DefaultScheme],
+			fields:   episode,
+			expected: "Broadchurch - 1x3 - Episode 3",
+		},
+		{
+			name:     "Default Movie",
+			scheme:   Schemes[DefaultScheme],
+			fields:   movie,
+			movie:    true,
+			expected: "Inception (2010)",
+		},
+		{
+			name:     "Plex TV",
+			scheme:   Schemes["plex"],
+			fields:   episode,
+			expected: "Broadchurch - S01E03 - Episode 3",
+		},
+		{
+			name:     "Default Multi-Episode",
+			scheme:   Schemes[DefaultScheme],
+			fields:   multiEpisode,
+			expected: "Broadchurch - 1x1-2 - Episode 1 + Episode 2",
+		},
+		{
+			name:     "Plex Multi-Episode",
+			scheme:   Schemes["plex"],
+			fields:   multiEpisode,
+			expected: "Broadchurch - S01E01-E02 - Episode 1 + Episode 2",
+		},
+		{
+			name: "Custom Multi-Episode",
+			scheme: mustScheme("custom", &Templates{
+				Movie: "{{.Title}}",
+				TV:    "{{.Title}} {{range .Episodes}}E{{pad 2 .}}{{end}} {{join .EpisodeTitles \" & \"}}",
+			}),
+			fields:   multiEpisode,
+			expected: "Broadchurch E01E02 Episode 1 & Episode 2",
+		},
+		{
+			name: "Custom TV",
+			scheme: mustScheme("custom", &Templates{
+				Movie: "{{.Title}}",
+				TV:    "{{.Title}} ({{.Year}}) {{pad 3 .Episode}} [{{.Resolution}}] {{.Provider}}-{{.ID}}",
+			}),
+			fields:   episode,
+			expected: "Broadchurch (2013) 003 [720p] TMDB-1427",
+		},
+		{
+			name: "Optional Tag Present",
+			scheme: mustScheme("custom", &Templates{
+				Movie: "{{.Title}} ({{.Year}}){{if .Quality}} [{{.Quality}}]{{end}}",
+				TV:    "{{.Title}}",
+			}),
+			fields:   movie,
+			movie:    true,
+			expected: "Inception (2010) [BluRay]",
+		},
+		{
+			name: "Optional Tag Missing",
+			scheme: mustScheme("custom", &Templates{
+				Movie: "{{.Title}} ({{.Year}}){{if .Resolution}} [{{.Resolution}}]{{end}}",
+				TV:    "{{.Title}}",
+			}),
+			fields:   movie,
+			movie:    true,
+			expected: "Inception (2010)",
+		},
+		{
+			name:     "Plex IDs Movie",
+			scheme:   Schemes["plex-ids"],
+			fields:   movie,
+			movie:    true,
+			expected: "Inception (2010) {tmdb-27205}",
+		},
+		{
+			name:     "Jellyfin IDs Movie",
+			scheme:   Schemes["jellyfin-ids"],
+			fields:   movie,
+			movie:    true,
+			expected: "Inception (2010) [tmdbid-27205]",
+		},
+		{
+			name:     "Jellyfin IDs Movie - IMDb Only",
+			scheme:   Schemes["jellyfin-ids"],
+			fields:   &Fields{Title: "Inception", Year: 2010, IMDB: "tt1375666"},
+			movie:    true,
+			expected: "Inception (2010) [imdbid-tt1375666]",
+		},
+		{
+			name:     "Plex IDs Movie - No IDs",
+			scheme:   Schemes["plex-ids"],
+			fields:   &Fields{Title: "Inception", Year: 2010},
+			movie:    true,
+			expected: "Inception (2010)",
+		},
+		{
+			name:     "Plex IDs TV",
+			scheme:   Schemes["plex-ids"],
+			fields:   episode,
+			expected: "Broadchurch - S01E03 - Episode 3",
+		},
+	}
+
+	for _, test := range tests {
+		var actual string
+		var err error
+		if test.movie {
+			actual, err = test.scheme.Movie(test.fields)
+		} else {
+			actual, err = test.scheme.TV(test.fields)
+		}
+
+		if err != nil {
+			t.Errorf("%s unexpected error: %s", test.name, err.Error())
+			continue
+		}
+
+		if actual != test.expected {
+			t.Errorf("%s expected %q, got %q", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestNewScheme_Invalid(t *testing.T) {
+
+	var tests = []struct {
+		name  string
+		movie string
+		tv    string
+	}{
+		{
+			name:  "Empty Template",
+			movie: "{{.Title}}",
+			tv:    " ",
+		},
+		{
+			name:  "Syntax Error",
+			movie: "{{.Title",
+			tv:    "{{.Title}}",
+		},
+		{
+			name:  "Unknown Field",
+			movie: "{{.Title}} ({{.Released}})",
+			tv:    "{{.Title}}",
+		},
+		{
+			name:  "Unknown Function",
+			movie: "{{.Title}}",
+			tv:    "{{upper .Title}}",
+		},
+	}
+
+	for _, test := range tests {
+		if _, err := NewScheme("invalid", &Templates{Movie: test.movie, TV: test.tv}); err == nil {
+			t.Errorf("%s expected error, got none", test.name)
+		}
+	}
+}
+
+func TestScheme_Dirs(t *testing.T) {
+
+	custom := mustScheme("custom", &Templates{
+		Movie:    "{{.Title}}",
+		TV:       "{{.Title}}",
+		MovieDir: "Movies/{{.Title}}",
+		TVDir:    "../{{.Title}}",
+	})
+
+	var tests = []struct {
+		name     string
+		dir      func(*Fields) (string, error)
+		fields   *Fields
+		expected string
+		err      bool
+	}{
+		{
+			name:     "Default Movie Dir",
+			dir:      Schemes[DefaultScheme].MovieDir,
+			fields:   &Fields{Title: "Captain Marvel", Year: 2019},
+			expected: "Captain Marvel (2019)",
+		},
+		{
+			name:     "Default TV Dir",
+			dir:      Schemes[DefaultScheme].TVDir,
+			fields:   &Fields{Title: "The Wire", Season: 1, Episode: 3},
+			expected: filepath.Join("The Wire", "Season 01"),
+		},
+		{
+			name:     "Default Specials Dir",
+			dir:      Schemes[DefaultScheme].TVDir,
+			fields:   &Fields{Title: "The Wire", Season: 0, Episode: 1},
+			expected: filepath.Join("The Wire", "Specials"),
+		},
+		{
+			name:     "Plex IDs TV Dir",
+			dir:      Schemes["plex-ids"].TVDir,
+			fields:   &Fields{Title: "The Wire", Season: 1, Episode: 3, Provider: "TVDB", TMDB: 1438, TVDB: 79126},
+			expected: filepath.Join("The Wire {tvdb-79126}", "Season 01"),
+		},
+		{
+			name:     "Jellyfin IDs Movie Dir",
+			dir:      Schemes["jellyfin-ids"].MovieDir,
+			fields:   &Fields{Title: "Captain Marvel", Year: 2019, Provider: "TMDB", TMDB: 299537, IMDB: "tt4154664"},
+			expected: "Captain Marvel (2019) [tmdbid-299537]",
+		},
+		{
+			name:     "Custom Movie Dir",
+			dir:      custom.MovieDir,
+			fields:   &Fields{Title: "Captain Marvel", Year: 2019},
+			expected: filepath.Join("Movies", "Captain Marvel"),
+		},
+		{
+			name:   "Dir Outside Library",
+			dir:    custom.TVDir,
+			fields: &Fields{Title: "The Wire", Season: 1, Episode: 3},
+			err:    true,
+		},
+	}
+
+	for _, test := range tests {
+		actual, err := test.dir(test.fields)
+		if test.err {
+			if err == nil {
+				t.Errorf("%s expected error, got none", test.name)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%s unexpected error: %s", test.name, err.Error())
+			continue
+		}
+
+		if actual != test.expected {
+			t.Errorf("%s expected %q, got %q", test.name, test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package naming
+
+import (
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	maxNameBytes = 255 //limit of most file systems
+)
+
+var (
+	//characters Windows doesn't allow in names, other than : / and \ which
+	//have their own rules
+	illegalChars = `<>"|?*`
+
+	//names Windows reserves for devices, with or without an extension
+	reservedNames = map[string]struct{}{
+		"CON": {}, "PRN": {}, "AUX": {}, "NUL": {},
+		"COM1": {}, "COM2": {}, "COM3": {}, "COM4": {}, "COM5": {}, "COM6": {}, "COM7": {}, "COM8": {}, "COM9": {},
+		"LPT1": {}, "LPT2": {}, "LPT3": {}, "LPT4": {}, "LPT5": {}, "LPT6": {}, "LPT7": {}, "LPT8": {}, "LPT9": {},
+	}
+
+	//letters that don't decompose into an ASCII letter and accents
+	transliterations = map[rune]string{
+		'ß': "ss", 'æ': "ae", 'Æ': "AE", 'œ': "oe", 'Œ': "OE", 'ø': "o", 'Ø': "O",
+		'đ': "d", 'Đ': "D", 'ð': "d", 'Ð': "D", 'þ': "th", 'Þ': "Th", 'ł': "l", 'Ł': "L", 'ı': "i",
+		'‘': "'", '’': "'", '“': `"`, '”': `"`, '–': "-", '—': "-", '…': "...",
+	}
+)
+
+//Sanitiser makes titles safe to use in file and directory names on all
+//common file systems. The replacements are configurable, the rest (removal
+//of control characters, trailing dots and spaces, reserved names and
+//truncation) are always applied.
+type Sanitiser struct {
+	Colon    string `json:"colon"`    //replaces :
+	Slash    string `json:"slash"`    //replaces / and \
+	Illegal  string `json:"illegal"`  //replaces the other characters Windows doesn't allow, <>"|?*
+	ASCII    bool   `json:"ascii"`    //transliterate to ASCII, e.g. Pokémon to Pokemon
+	MaxBytes int    `json:"maxBytes"` //maximum length of a file name, including extension
+}
+
+//NewSanitiser returns a sanitiser with the default rules, e.g.
+//"Star Wars: Episode IV" becomes "Star Wars - Episode IV"
+func NewSanitiser() *Sanitiser {
+	return &Sanitiser{
+		Colon:    " - ",
+		Slash:    "-",
+		Illegal:  "",
+		MaxBytes: maxNameBytes,
+	}
+}
+
+//Fields returns a copy of fields with its text sanitised, so values can't
+//add directories or illegal characters to names
+func (s *Sanitiser) Fields(fields *Fields) *Fields {
+
+	sanitised := *fields
+	for _, value := range []*string{
+		&sanitised.Title,
+		&sanitised.EpisodeTitle,
+		&sanitised.Provider,
+		&sanitised.Resolution,
+		&sanitised.Quality,
+		&sanitised.Codec,
+		&sanitised.Audio,
+		&sanitised.Group,
+	} {
+		*value = s.text(*value)
+	}
+
+	if fields.EpisodeTitles != nil {
+		sanitised.EpisodeTitles = make([]string, len(fields.EpisodeTitles))
+		for i, title := range fields.EpisodeTitles {
+			sanitised.EpisodeTitles[i] = s.text(title)
+		}
+	}
+
+	return &sanitised
+}
+
+//Name sanitises a file name (without extension), truncating it so that
+//along with ext it fits within the byte limit
+func (s *Sanitiser) Name(name, ext string) string {
+
+	name = s.text(name)
+	name = truncate(name, s.maxBytes()-len(ext))
+	name = strings.TrimRight(name, ". ") //also after truncating, which may expose them
+
+	//device names are reserved regardless of extension
+	stem := strings.ToUpper(strings.SplitN(name, ".", 2)[0])
+	if _, ok := reservedNames[stem]; ok {
+		name += "_"
+	}
+
+	return name
+}
+
+//Dir sanitises each directory of a relative path
+func (s *Sanitiser) Dir(dir string) string {
+
+	var sanitised []string
+	for _, part := range strings.Split(dir, string(filepath.Separator)) {
+		if part = s.Name(part, ""); part != "" {
+			sanitised = append(sanitised, part)
+		}
+	}
+
+	return filepath.Join(sanitised...)
+}
+
+//applies the replacement rules to text, normalising it to NFC so names
+//compare equal however the database composed them
+func (s *Sanitiser) text(text string) string {
+
+	text = norm.NFC.String(text)
+
+	if s.ASCII {
+		text = ascii(text)
+	}
+
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == ':':
+			b.WriteString(s.Colon)
+		case r == '/' || r == '\\':
+			b.WriteString(s.Slash)
+		case strings.ContainsRune(illegalChars, r):
+			b.WriteString(s.Illegal)
+		case unicode.IsSpace(r):
+			b.WriteRune(' ') //tabs and new lines
+		case unicode.IsControl(r):
+			//dropped
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	//replacements can leave doubled or leading spaces
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+func (s *Sanitiser) maxBytes() int {
+
+	if s.MaxBytes <= 0 || s.MaxBytes > maxNameBytes {
+		return maxNameBytes
+	}
+
+	return s.MaxBytes
+}
+
+//transliterates text to ASCII by removing accents and replacing letters
+//that have a common ASCII spelling. Any other characters are dropped,
+//unless that would drop all of them (e.g. for a Japanese title).
+func ascii(text string) string {
+
+	stripped, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), text)
+	if err != nil {
+		return text
+	}
+
+	var b strings.Builder
+	for _, r := range stripped {
+		if r < utf8.RuneSelf {
+			b.WriteRune(r)
+		} else if t, ok := transliterations[r]; ok {
+			b.WriteString(t)
+		}
+	}
+
+	if strings.TrimSpace(b.String()) == "" {
+		return text
+	}
+
+	return b.String()
+}
+
+//shortens text to at most max bytes without splitting a character
+func truncate(text string, max int) string {
+
+	if len(text) <= max {
+		return text
+	}
+
+	if max < 0 {
+		max = 0
+	}
+
+	for max > 0 && !utf8.RuneStart(text[max]) {
+		max--
+	}
+
+	return text[:max]
+}
@@ -0,0 +1,187 @@
+package naming
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSanitiser_Name(t *testing.T) {
+
+	ascii := NewSanitiser()
+	ascii.ASCII = true
+
+	custom := &Sanitiser{
+		Colon:   "",
+		Slash:   "_",
+		Illegal: "_",
+	}
+
+	var tests = []struct {
+		name      string
+		sanitiser *Sanitiser
+		input     string
+		ext       string
+		expected  string
+	}{
+		{
+			name:      "Trailing Dot",
+			sanitiser: NewSanitiser(),
+			input:     "Marvel's Agents of S.H.I.E.L.D.",
+			ext:       ".mkv",
+			expected:  "Marvel's Agents of S.H.I.E.L.D",
+		},
+		{
+			name:      "Colon",
+			sanitiser: NewSanitiser(),
+			input:     "Star Wars: Episode IV - A New Hope (1977)",
+			ext:       ".mkv",
+			expected:  "Star Wars - Episode IV - A New Hope (1977)",
+		},
+		{
+			name:      "Slash",
+			sanitiser: NewSanitiser(),
+			input:     "Face/Off (1997)",
+			ext:       ".mkv",
+			expected:  "Face-Off (1997)",
+		},
+		{
+			name:      "Illegal And Control Characters",
+			sanitiser: NewSanitiser(),
+			input:     "Who Are You?\t<Part 1>\x00",
+			ext:       ".mkv",
+			expected:  "Who Are You Part 1",
+		},
+		{
+			name:      "Custom Rules",
+			sanitiser: custom,
+			input:     "Face/Off: What?",
+			ext:       ".mkv",
+			expected:  "Face_Off What_",
+		},
+		{
+			name:      "Reserved Name",
+			sanitiser: NewSanitiser(),
+			input:     "Con",
+			ext:       ".mkv",
+			expected:  "Con_",
+		},
+		{
+			name:      "NFC",
+			sanitiser: NewSanitiser(),
+			input:     "Pokémon",
+			ext:       ".mkv",
+			expected:  "Pokémon",
+		},
+		{
+			name:      "ASCII",
+			sanitiser: ascii,
+			input:     "Pokémon – Straße “Æon”",
+			ext:       ".mkv",
+			expected:  "Pokemon - Strasse AEon", //quotes are illegal
+		},
+		{
+			name:      "ASCII Untransliterable",
+			sanitiser: ascii,
+			input:     "進撃の巨人",
+			ext:       ".mkv",
+			expected:  "進撃の巨人",
+		},
+		{
+			name:      "Truncated",
+			sanitiser: NewSanitiser(),
+			input:     strings.Repeat("é", 200),
+			ext:       ".mkv",
+			expected:  strings.Repeat("é", 125), //2 bytes each, 251 bytes
+		},
+	}
+
+	for _, test := range tests {
+		actual := test.sanitiser.Name(test.input, test.ext)
+		if actual != test.expected {
+			t.Errorf("%s expected %q, got %q", test.name, test.expected, actual)
+		}
+
+		if len(actual)+len(test.ext) > maxNameBytes {
+			t.Errorf("%s name too long: %d bytes", test.name, len(actual)+len(test.ext))
+		}
+	}
+}
+
+func TestSanitiser_Dir(t *testing.T) {
+
+	sanitiser := NewSanitiser()
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Trailing Dots",
+			input:    filepath.Join("Marvel's Agents of S.H.I.E.L.D.", "Season 01"),
+			expected: filepath.Join("Marvel's Agents of S.H.I.E.L.D", "Season 01"),
+		},
+		{
+			name:     "Empty Directory",
+			input:    filepath.Join("...", "Season 01"),
+			expected: "Season 01",
+		},
+	}
+
+	for _, test := range tests {
+		if actual := sanitiser.Dir(test.input); actual != test.expected {
+			t.Errorf("%s expected %q, got %q", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestSanitiser_Fields(t *testing.T) {
+
+	scheme := Schemes["plex"]
+	fields := &Fields{
+		Title:        "Doctor Who",
+		Season:       3,
+		Episode:      10,
+		EpisodeTitle: "Blink / Part 1: Angels",
+	}
+
+	dir, err := scheme.TVDir(NewSanitiser().Fields(fields))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	name, err := scheme.TV(NewSanitiser().Fields(fields))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if expected := filepath.Join("Doctor Who", "Season 03"); dir != expected {
+		t.Errorf("expected dir %q, got %q", expected, dir)
+	}
+
+	if expected := "Doctor Who - S03E10 - Blink - Part 1 - Angels"; name != expected {
+		t.Errorf("expected name %q, got %q", expected, name)
+	}
+
+	if fields.EpisodeTitle != "Blink / Part 1: Angels" {
+		t.Errorf("original fields modified: %q", fields.EpisodeTitle)
+	}
+}
+
+func TestSanitiser_Fields_EpisodeTitles(t *testing.T) {
+
+	fields := &Fields{
+		EpisodeTitles: []string{"Part 1: Angels", "Part 2/3"},
+	}
+
+	sanitised := NewSanitiser().Fields(fields)
+
+	if actual, expected := strings.Join(sanitised.EpisodeTitles, "|"), "Part 1 - Angels|Part 2-3"; actual != expected {
+		t.Errorf("expected titles %q, got %q", expected, actual)
+	}
+
+	if fields.EpisodeTitles[0] != "Part 1: Angels" {
+		t.Errorf("original fields modified: %q", fields.EpisodeTitles[0])
+	}
+}
@@ -0,0 +1,127 @@
+package naming
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	minSpecialTitle = 3 //length of the shortest single word title of a show with specials
+)
+
+var (
+	//separators between the words of a name, e.g. Show.Christmas_Special
+	wordSep = regexp.MustCompile(`[\s._()\[\]{}:,!?'-]+`)
+
+	//words marking a file as a special or extra, rather than a movie or a
+	//numbered episode
+	specialMarkers = map[string]bool{
+		"special":    true,
+		"specials":   true,
+		"extra":      true,
+		"extras":     true,
+		"featurette": true,
+		"bonus":      true,
+		"ova":        true,
+		"oad":        true,
+	}
+
+	//quality and release tags, which end the words of a name
+	releaseTag = regexp.MustCompile(`(?i)^(?:\d{3,4}[pi]|hdtv|pdtv|web|webrip|webdl|dl|bluray|bdrip|brrip|dvdrip|hdrip|x26[45]|h26[45]|hevc|xvid|aac|ac3|dts|proper|repack|internal)$`)
+
+	//words too common to help tell specials apart
+	stopWords = map[string]bool{
+		"a":   true,
+		"an":  true,
+		"and": true,
+		"in":  true,
+		"of":  true,
+		"on":  true,
+		"the": true,
+		"to":  true,
+	}
+)
+
+//ParseSpecial returns the title and, if given, year of the show of a special
+//or extra which isn't numbered, e.g. "Doctor.Who.2005.Christmas.Special.2010"
+//is a special of Doctor Who (2005). The title is the words before the year or
+//special marker, so may run on into the special's title. The title is empty
+//and the year 0 if the name isn't an unnumbered special, or its title is only
+//common words or a short word.
+func ParseSpecial(name string) (string, int) {
+
+	if firstEpisode.MatchString(name) {
+		return "", 0
+	}
+
+	words := nameWords(name)
+
+	marker := -1
+	for i, word := range words {
+		if specialMarkers[strings.ToLower(word)] {
+			marker = i
+			break
+		}
+	}
+	if marker < 1 {
+		return "", 0
+	}
+
+	title, year := words[:marker], 0
+	for i, word := range title {
+		if i > 0 && isYear(word) {
+			title = title[:i]
+			year, _ = strconv.Atoi(word)
+			break
+		}
+	}
+
+	//titles of only common words or a short word are likely the start of a
+	//movie's title, e.g. "The.Extra.Man.2010"
+	if len(title) == 1 && len(title[0]) < minSpecialTitle {
+		return "", 0
+	}
+	for _, word := range title {
+		if !stopWords[strings.ToLower(word)] {
+			return strings.Join(title, " "), year
+		}
+	}
+
+	return "", 0
+}
+
+//returns the words of a name up to its quality and release tags, e.g.
+//"Show.Christmas.Special.720p.HDTV" is Show, Christmas and Special
+func nameWords(name string) []string {
+
+	var words []string
+	for _, word := range wordSep.Split(name, -1) {
+		if releaseTag.MatchString(word) {
+			break
+		}
+		if word != "" {
+			words = append(words, word)
+		}
+	}
+
+	return words
+}
+
+//SpecialWords returns the lower case words of a special's name which may be
+//part of its title, leaving out special markers, common words and single
+//letters, e.g. "Doctor.Who.Christmas.Special.2010" is doctor, who,
+//christmas and 2010
+func SpecialWords(name string) []string {
+
+	var words []string
+	for _, word := range nameWords(name) {
+		word = strings.ToLower(word)
+		if specialMarkers[word] || stopWords[word] || (len(word) == 1 && !isDigit(word[0])) {
+			continue
+		}
+		words = append(words, word)
+	}
+
+	return words
+}
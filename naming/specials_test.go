@@ -0,0 +1,98 @@
+package naming
+
+import (
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestParseSpecial(t *testing.T) {
+
+	var tests = []struct {
+		name  string
+		input string
+		title string
+		year  int
+	}{
+		{
+			name:  "Show Year and Special Year",
+			input: "Doctor.Who.2005.Christmas.Special.2010.720p.HDTV.x264",
+			title: "Doctor Who",
+			year:  2005,
+		},
+		{
+			name:  "Special Title Before Marker",
+			input: "Sherlock.The.Abominable.Bride.Special.1080p",
+			title: "Sherlock The Abominable Bride",
+		},
+		{
+			name:  "Special Title After Marker",
+			input: "Top Gear - Special - Bolivia [720p]",
+			title: "Top Gear",
+		},
+		{
+			name:  "Extras",
+			input: "Band.of.Brothers.Extras.We.Stand.Alone.Together",
+			title: "Band of Brothers",
+		},
+		{
+			name:  "Numbered Special",
+			input: "Doctor.Who.2005.S00E05.Christmas.Special",
+		},
+		{
+			name:  "Common Word Title",
+			input: "The.Extra.Man.2010.1080p",
+		},
+		{
+			name:  "Short Title",
+			input: "Up.Extras.2009",
+		},
+		{
+			name:  "No Show Title",
+			input: "Special.Forces.2011.1080p",
+		},
+		{
+			name:  "Episode",
+			input: "Doctor.Who.2005.S05E01.720p",
+		},
+	}
+
+	for _, test := range tests {
+		title, year := ParseSpecial(test.input)
+
+		if title != test.title || year != test.year {
+			t.Errorf("%s expected %q %d, got %q %d", test.name, test.title, test.year, title, year)
+		}
+	}
+}
+
+func TestSpecialWords(t *testing.T) {
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "File Name",
+			input:    "Doctor.Who.2005.Christmas.Special.2010.720p.HDTV.x264",
+			expected: []string{"doctor", "who", "2005", "christmas", "2010"},
+		},
+		{
+			name:     "Episode Title",
+			input:    "A Christmas Carol",
+			expected: []string{"christmas", "carol"},
+		},
+		{
+			name:     "Punctuation",
+			input:    "Here's How It Was: The Making of Firefly",
+			expected: []string{"here", "how", "it", "was", "making", "firefly"},
+		},
+	}
+
+	for _, test := range tests {
+		if diff := pretty.Compare(test.expected, SpecialWords(test.input)); diff != "" {
+			t.Errorf("%s unexpected diff (-want +got):\n%s", test.name, diff)
+		}
+	}
+}
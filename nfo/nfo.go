@@ -0,0 +1,187 @@
+package nfo
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rustedturnip/media-mapper/types"
+)
+
+const (
+	Ext        = ".nfo"
+	TVShowFile = "tvshow.nfo"
+
+	dateFormat = "2006-01-02"
+)
+
+//UniqueID is an ID of the media in a database, such as TMDB
+type UniqueID struct {
+	Type    string `xml:"type,attr"`
+	Default bool   `xml:"default,attr,omitempty"`
+	ID      string `xml:",chardata"`
+}
+
+//Movie is the nfo of a movie, written beside it as <movie name>.nfo
+type Movie struct {
+	XMLName   xml.Name    `xml:"movie"`
+	Title     string      `xml:"title"`
+	Year      int         `xml:"year,omitempty"`
+	Plot      string      `xml:"plot,omitempty"`
+	Premiered string      `xml:"premiered,omitempty"`
+	UniqueIDs []*UniqueID `xml:"uniqueid"`
+}
+
+//TVShow is the nfo of a show, written in the show's directory as tvshow.nfo
+type TVShow struct {
+	XMLName   xml.Name    `xml:"tvshow"`
+	Title     string      `xml:"title"`
+	Year      int         `xml:"year,omitempty"`
+	Plot      string      `xml:"plot,omitempty"`
+	Premiered string      `xml:"premiered,omitempty"`
+	UniqueIDs []*UniqueID `xml:"uniqueid"`
+}
+
+//Episode is the nfo of an episode, written beside it as <episode name>.nfo
+type Episode struct {
+	XMLName   xml.Name    `xml:"episodedetails"`
+	Title     string      `xml:"title"`
+	ShowTitle string      `xml:"showtitle"`
+	Season    int         `xml:"season"`
+	Episode   int         `xml:"episode"`
+	Plot      string      `xml:"plot,omitempty"`
+	Aired     string      `xml:"aired,omitempty"`
+	UniqueIDs []*UniqueID `xml:"uniqueid"`
+}
+
+func NewMovie(provider string, movie *types.Movie) *Movie {
+	return &Movie{
+		Title:     movie.Title,
+		Year:      year(movie.ReleaseDate),
+		Plot:      movie.Overview,
+		Premiered: date(movie.ReleaseDate),
+		UniqueIDs: uniqueIDs(provider, movie.ID, movie.ExternalIDs),
+	}
+}
+
+func NewTVShow(provider string, show *types.TV) *TVShow {
+	return &TVShow{
+		Title:     show.Title,
+		Year:      year(show.ReleaseDate),
+		Plot:      show.Overview,
+		Premiered: date(show.ReleaseDate),
+		UniqueIDs: uniqueIDs(provider, show.ID, show.ExternalIDs),
+	}
+}
+
+func NewEpisode(provider string, show *types.TV, series *types.Series, episode *types.Episode) *Episode {
+	return &Episode{
+		Title:     episode.Title,
+		ShowTitle: show.Title,
+		Season:    series.Number,
+		Episode:   episode.Number,
+		Plot:      episode.Overview,
+		Aired:     date(episode.AirDate),
+		UniqueIDs: uniqueIDs(provider, episode.ID, episode.ExternalIDs),
+	}
+}
+
+//NewEpisodes returns the nfo of each of a file's episodes, which Kodi reads
+//from one file for multi-episode files
+func NewEpisodes(provider string, show *types.TV, series *types.Series, episodes []*types.Episode) []*Episode {
+
+	nfos := make([]*Episode, 0, len(episodes))
+	for _, episode := range episodes {
+		nfos = append(nfos, NewEpisode(provider, show, series, episode))
+	}
+
+	return nfos
+}
+
+//Write writes the nfo (a Movie, TVShow, Episode or the Episodes of a
+//multi-episode file) to path, returning an error if a file already exists
+//there
+func Write(path string, nfo interface{}) error {
+
+	data, err := xml.MarshalIndent(nfo, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(append([]byte(xml.Header), append(data, '\n')...)); err != nil {
+		file.Close()
+		os.Remove(path)
+		return err
+	}
+
+	return file.Close()
+}
+
+//ShowDir returns the directory of the show an episode in dir belongs to, the
+//parent of dir if it's a season directory (e.g. "Season 01" or "Specials")
+func ShowDir(dir string) string {
+
+	name := strings.ToLower(filepath.Base(dir))
+	if strings.HasPrefix(name, "season") || name == "specials" {
+		return filepath.Dir(dir)
+	}
+
+	return dir
+}
+
+//returns the IDs of the media, the provider's ID (the default) followed by
+//the external IDs it has in other databases
+func uniqueIDs(provider string, id int, external types.ExternalIDs) []*UniqueID {
+
+	var ids []*UniqueID
+	if id != 0 && provider != "" {
+		ids = append(ids, &UniqueID{
+			Type:    strings.ToLower(provider),
+			Default: true,
+			ID:      strconv.Itoa(id),
+		})
+	}
+
+	add := func(kind, id string) {
+		if id == "" || strings.EqualFold(kind, provider) {
+			return
+		}
+		ids = append(ids, &UniqueID{Type: kind, ID: id})
+	}
+
+	add("imdb", external.IMDB)
+	if external.TMDB != 0 {
+		add("tmdb", strconv.Itoa(external.TMDB))
+	}
+	if external.TVDB != 0 {
+		add("tvdb", strconv.Itoa(external.TVDB))
+	}
+
+	return ids
+}
+
+func year(t time.Time) int {
+
+	if t.IsZero() {
+		return 0
+	}
+
+	return t.Year()
+}
+
+func date(t time.Time) string {
+
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(dateFormat)
+}
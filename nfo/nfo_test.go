@@ -0,0 +1,157 @@
+package nfo
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/rustedturnip/media-mapper/types"
+)
+
+func TestWrite(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "media-mapper-nfo")
+	if err != nil {
+		t.Fatalf("unable to create dir: %s", err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	show := &types.TV{
+		ID:          1,
+		Title:       "Broadchurch",
+		Overview:    "A murder in a seaside town.",
+		ReleaseDate: time.Date(2013, 3, 4, 0, 0, 0, 0, time.UTC),
+		ExternalIDs: types.ExternalIDs{IMDB: "tt2249364", TVDB: 1}, //same show in TVDB
+	}
+	series := &types.Series{
+		Number: 2,
+	}
+	episode := &types.Episode{
+		ID:          20,
+		Title:       "Episode 1",
+		Number:      1,
+		Overview:    "Joe Miller pleads not guilty & the trial begins.",
+		AirDate:     time.Date(2015, 1, 5, 0, 0, 0, 0, time.UTC),
+		ExternalIDs: types.ExternalIDs{IMDB: "tt4276514"},
+	}
+	nextEpisode := &types.Episode{
+		ID:     21,
+		Title:  "Episode 2",
+		Number: 2,
+	}
+	movie := &types.Movie{
+		Title: "The Lion King",
+	}
+
+	var tests = []struct {
+		name     string
+		nfo      interface{}
+		expected string
+	}{
+		{
+			name: "Episode",
+			nfo:  NewEpisode("TMDB", show, series, episode),
+			expected: `<?xml version="1.0" encoding="UTF-8"?>
+<episodedetails>
+  <title>Episode 1</title>
+  <showtitle>Broadchurch</showtitle>
+  <season>2</season>
+  <episode>1</episode>
+  <plot>Joe Miller pleads not guilty &amp; the trial begins.</plot>
+  <aired>2015-01-05</aired>
+  <uniqueid type="tmdb" default="true">20</uniqueid>
+  <uniqueid type="imdb">tt4276514</uniqueid>
+</episodedetails>
+`,
+		},
+		{
+			name: "Multi-Episode",
+			nfo:  NewEpisodes("TMDB", show, series, []*types.Episode{episode, nextEpisode}),
+			expected: `<?xml version="1.0" encoding="UTF-8"?>
+<episodedetails>
+  <title>Episode 1</title>
+  <showtitle>Broadchurch</showtitle>
+  <season>2</season>
+  <episode>1</episode>
+  <plot>Joe Miller pleads not guilty &amp; the trial begins.</plot>
+  <aired>2015-01-05</aired>
+  <uniqueid type="tmdb" default="true">20</uniqueid>
+  <uniqueid type="imdb">tt4276514</uniqueid>
+</episodedetails>
+<episodedetails>
+  <title>Episode 2</title>
+  <showtitle>Broadchurch</showtitle>
+  <season>2</season>
+  <episode>2</episode>
+  <uniqueid type="tmdb" default="true">21</uniqueid>
+</episodedetails>
+`,
+		},
+		{
+			name: "TV Show",
+			nfo:  NewTVShow("TVDB", show),
+			expected: `<?xml version="1.0" encoding="UTF-8"?>
+<tvshow>
+  <title>Broadchurch</title>
+  <year>2013</year>
+  <plot>A murder in a seaside town.</plot>
+  <premiered>2013-03-04</premiered>
+  <uniqueid type="tvdb" default="true">1</uniqueid>
+  <uniqueid type="imdb">tt2249364</uniqueid>
+</tvshow>
+`,
+		},
+		{
+			name: "Movie Without Details",
+			nfo:  NewMovie("TMDB", movie),
+			expected: `<?xml version="1.0" encoding="UTF-8"?>
+<movie>
+  <title>The Lion King</title>
+</movie>
+`,
+		},
+	}
+
+	for _, test := range tests {
+		path := filepath.Join(dir, test.name+Ext)
+		if err := Write(path, test.nfo); err != nil {
+			t.Errorf("%s unexpected error: %s", test.name, err.Error())
+			continue
+		}
+
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatalf("unable to read nfo: %s", err.Error())
+		}
+
+		if diff := pretty.Compare(test.expected, string(data)); diff != "" {
+			t.Errorf("%s unexpected diff (-want +got):\n%s", test.name, diff)
+		}
+
+		//existing files are left alone
+		if err := Write(path, test.nfo); !os.IsExist(err) {
+			t.Errorf("%s expected error writing existing file, got %v", test.name, err)
+		}
+	}
+}
+
+func TestShowDir(t *testing.T) {
+
+	var tests = []struct {
+		dir      string
+		expected string
+	}{
+		{dir: "/tv/Broadchurch/Season 02", expected: "/tv/Broadchurch"},
+		{dir: "/tv/Broadchurch/Specials", expected: "/tv/Broadchurch"},
+		{dir: "/tv/Broadchurch", expected: "/tv/Broadchurch"},
+	}
+
+	for _, test := range tests {
+		if actual := ShowDir(filepath.FromSlash(test.dir)); actual != filepath.FromSlash(test.expected) {
+			t.Errorf("ShowDir(%q) expected %q, got %q", test.dir, test.expected, actual)
+		}
+	}
+}
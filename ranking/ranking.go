@@ -0,0 +1,220 @@
+package ranking
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/agnivade/levenshtein"
+	"github.com/rustedturnip/media-mapper/types"
+)
+
+//weights of each component of a candidate's confidence, components that
+//can't be scored for a query (e.g. year when none was parsed) are left out
+//and the remaining weights are scaled up to compensate
+const (
+	titleWeight      = 0.55
+	yearWeight       = 0.25
+	popularityWeight = 0.10
+	episodeWeight    = 0.10
+)
+
+//number of leading tv candidates checked for the queried episode
+const episodeChecks = 3
+
+var (
+	//trailing qualifiers databases add to titles, e.g. "Taboo (2017)" or "The Office (US)"
+	titleQualifier = regexp.MustCompile(`\s*\([^)]*\)\s*$`)
+)
+
+//Query holds the details parsed from a file name that candidates are scored against
+type Query struct {
+	Title   string
+	Year    int //0 if unknown
+	Season  int
+	Episode int
+}
+
+type MovieMatch struct {
+	Movie      *types.Movie
+	Confidence float64 //0 (no confidence) to 1 (certain)
+}
+
+type TVMatch struct {
+	TV         *types.TV
+	Confidence float64 //0 (no confidence) to 1 (certain)
+}
+
+//score accumulates weighted components into a normalised confidence
+type score struct {
+	total  float64
+	weight float64
+}
+
+func (s *score) add(value, weight float64) {
+	s.total += value * weight
+	s.weight += weight
+}
+
+func (s *score) confidence() float64 {
+	if s.weight == 0 {
+		return 0
+	}
+	return s.total / s.weight
+}
+
+//Movies scores every movie against the query and returns them best match first
+func Movies(query Query, movies []*types.Movie) []*MovieMatch {
+
+	maxVotes := 0
+	for _, movie := range movies {
+		if movie.VoteCount > maxVotes {
+			maxVotes = movie.VoteCount
+		}
+	}
+
+	matches := make([]*MovieMatch, 0, len(movies))
+	for _, movie := range movies {
+		s := &score{}
+		s.add(TitleSimilarity(query.Title, movie.Title), titleWeight)
+
+		if query.Year != 0 {
+			s.add(yearSimilarity(query.Year, movie.ReleaseDate.Year()), yearWeight)
+		}
+
+		s.add(popularity(movie.VoteCount, maxVotes), popularityWeight)
+
+		matches = append(matches, &MovieMatch{
+			Movie:      movie,
+			Confidence: s.confidence(),
+		})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Confidence > matches[j].Confidence
+	})
+
+	return matches
+}
+
+//EpisodeChecker reports whether a show has the queried episode
+type EpisodeChecker func(show *types.TV) bool
+
+//TV scores every show against the query and returns them best match first.
+//As checking for the episode may mean fetching the show, only the leading
+//candidates are checked, the rest are assumed not to have it.
+func TV(query Query, shows []*types.TV, hasEpisode EpisodeChecker) []*TVMatch {
+
+	maxVotes := 0
+	for _, show := range shows {
+		if show.VoteCount > maxVotes {
+			maxVotes = show.VoteCount
+		}
+	}
+
+	scores := make(map[*types.TV]*score)
+	matches := make([]*TVMatch, 0, len(shows))
+	for _, show := range shows {
+		s := &score{}
+		s.add(TitleSimilarity(query.Title, show.Title), titleWeight)
+
+		//shows are usually released before the year in the file name, so
+		//the year is only used when the show has a known release date
+		if query.Year != 0 && !show.ReleaseDate.IsZero() {
+			s.add(yearSimilarity(query.Year, show.ReleaseDate.Year()), yearWeight)
+		}
+
+		s.add(popularity(show.VoteCount, maxVotes), popularityWeight)
+
+		scores[show] = s
+		matches = append(matches, &TVMatch{
+			TV:         show,
+			Confidence: s.confidence(),
+		})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Confidence > matches[j].Confidence
+	})
+
+	for i, match := range matches {
+		s := scores[match.TV]
+
+		if i < episodeChecks && hasEpisode(match.TV) {
+			s.add(1, episodeWeight)
+		} else {
+			s.add(0, episodeWeight)
+		}
+
+		match.Confidence = s.confidence()
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Confidence > matches[j].Confidence
+	})
+
+	return matches
+}
+
+//TitleSimilarity compares two titles, ignoring case, punctuation and
+//trailing qualifiers, returning 0 (different) to 1 (identical)
+func TitleSimilarity(a, b string) float64 {
+
+	a, b = normaliseTitle(a), normaliseTitle(b)
+
+	longest := len([]rune(a))
+	if l := len([]rune(b)); l > longest {
+		longest = l
+	}
+
+	if longest == 0 {
+		return 0
+	}
+
+	return 1 - float64(levenshtein.ComputeDistance(a, b))/float64(longest)
+}
+
+func normaliseTitle(title string) string {
+
+	title = titleQualifier.ReplaceAllString(title, "")
+
+	//drop apostrophes (file names rarely keep them), replace remaining
+	//punctuation with whitespace, then collapse it
+	title = strings.Map(func(r rune) rune {
+		switch {
+		case r == '\'' || r == '’':
+			return -1
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			return unicode.ToLower(r)
+		default:
+			return ' '
+		}
+	}, title)
+
+	return strings.Join(strings.Fields(title), " ")
+}
+
+//release dates differ between regions so years either side still score
+func yearSimilarity(want, got int) float64 {
+
+	switch diff := want - got; {
+	case diff == 0:
+		return 1
+	case diff == 1 || diff == -1:
+		return 0.5
+	default:
+		return 0
+	}
+}
+
+//log scale so that a few very popular candidates don't flatten the rest
+func popularity(votes, maxVotes int) float64 {
+
+	if maxVotes <= 0 || votes <= 0 {
+		return 0
+	}
+
+	return math.Log1p(float64(votes)) / math.Log1p(float64(maxVotes))
+}
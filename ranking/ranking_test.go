@@ -0,0 +1,157 @@
+package ranking
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/rustedturnip/media-mapper/types"
+)
+
+func TestRanking_Movies(t *testing.T) {
+
+	lionKing1994 := &types.Movie{
+		Title:       "The Lion King",
+		ReleaseDate: time.Date(1994, 6, 23, 0, 0, 0, 0, time.UTC),
+		VoteCount:   13000,
+	}
+	lionKing2019 := &types.Movie{
+		Title:       "The Lion King",
+		ReleaseDate: time.Date(2019, 7, 12, 0, 0, 0, 0, time.UTC),
+		VoteCount:   7000,
+	}
+	lionKing2 := &types.Movie{
+		Title:       "The Lion King II: Simba's Pride",
+		ReleaseDate: time.Date(1998, 10, 27, 0, 0, 0, 0, time.UTC),
+		VoteCount:   2500,
+	}
+
+	var tests = []struct {
+		name     string
+		query    Query
+		input    []*types.Movie
+		expected []*types.Movie
+	}{
+		{
+			name:     "Remake - Year Decides",
+			query:    Query{Title: "The Lion King", Year: 2019},
+			input:    []*types.Movie{lionKing1994, lionKing2, lionKing2019},
+			expected: []*types.Movie{lionKing2019, lionKing1994, lionKing2},
+		},
+		{
+			name:     "Remake - No Year, Popularity Decides",
+			query:    Query{Title: "The Lion King"},
+			input:    []*types.Movie{lionKing2019, lionKing2, lionKing1994},
+			expected: []*types.Movie{lionKing1994, lionKing2019, lionKing2},
+		},
+		{
+			name:     "No Results",
+			query:    Query{Title: "The Lion King"},
+			input:    nil,
+			expected: []*types.Movie{},
+		},
+	}
+
+	for _, test := range tests {
+		var result []*types.Movie
+		for _, match := range Movies(test.query, test.input) {
+			result = append(result, match.Movie)
+		}
+
+		if result == nil {
+			result = []*types.Movie{}
+		}
+
+		if diff := pretty.Compare(test.expected, result); diff != "" {
+			t.Errorf("%s unexpected diff (-want +got):\n%s", test.name, diff)
+		}
+	}
+}
+
+func TestRanking_TV(t *testing.T) {
+
+	withEpisode := func(title string, season, episode int) *types.TV {
+		tv := types.NewTV()
+		tv.Title = title
+
+		series := types.NewSeries()
+		series.Number = season
+		series.Episodes[episode] = &types.Episode{Number: episode}
+		tv.Series[season] = series
+
+		return tv
+	}
+
+	wire := withEpisode("The Wire", 1, 3)
+	wireShort := withEpisode("The Wire", 1, 1)
+	wired := withEpisode("Wired", 1, 3)
+
+	var tests = []struct {
+		name     string
+		query    Query
+		input    []*types.TV
+		expected []*types.TV
+	}{
+		{
+			name:     "Same Title - Episode Existence Decides",
+			query:    Query{Title: "The Wire", Season: 1, Episode: 3},
+			input:    []*types.TV{wireShort, wired, wire},
+			expected: []*types.TV{wire, wireShort, wired},
+		},
+	}
+
+	for _, test := range tests {
+		var result []*types.TV
+		hasEpisode := func(show *types.TV) bool {
+			series, ok := show.Series[test.query.Season]
+			if !ok {
+				return false
+			}
+
+			_, ok = series.Episodes[test.query.Episode]
+			return ok
+		}
+
+		for _, match := range TV(test.query, test.input, hasEpisode) {
+			result = append(result, match.TV)
+		}
+
+		if diff := pretty.Compare(test.expected, result); diff != "" {
+			t.Errorf("%s unexpected diff (-want +got):\n%s", test.name, diff)
+		}
+	}
+}
+
+func TestRanking_TitleSimilarity(t *testing.T) {
+
+	var tests = []struct {
+		name     string
+		a, b     string
+		expected float64
+	}{
+		{
+			name:     "Identical",
+			a:        "Broadchurch",
+			b:        "Broadchurch",
+			expected: 1,
+		},
+		{
+			name:     "Case, Punctuation and Qualifier Ignored",
+			a:        "marvels agents of s h i e l d",
+			b:        "Marvel's Agents of S.H.I.E.L.D. (2013)",
+			expected: 1,
+		},
+		{
+			name:     "Empty",
+			a:        "",
+			b:        "",
+			expected: 0,
+		},
+	}
+
+	for _, test := range tests {
+		if result := TitleSimilarity(test.a, test.b); result != test.expected {
+			t.Errorf("%s expected %v, got %v", test.name, test.expected, result)
+		}
+	}
+}
@@ -30,6 +30,15 @@ func (mb *MovieBuilder) Build() *types.Movie {
 	return movie
 }
 
+func (mb *MovieBuilder) WithID(id int) *MovieBuilder {
+	mb.functions = append(mb.functions, func(m *types.Movie) error {
+		m.ID = id
+		return nil
+	})
+
+	return mb
+}
+
 func (mb *MovieBuilder) WithTitle(title string) *MovieBuilder {
 	mb.functions = append(mb.functions, func(m *types.Movie) error {
 		m.Title = title
@@ -39,6 +48,15 @@ func (mb *MovieBuilder) WithTitle(title string) *MovieBuilder {
 	return mb
 }
 
+func (mb *MovieBuilder) WithOverview(overview string) *MovieBuilder {
+	mb.functions = append(mb.functions, func(m *types.Movie) error {
+		m.Overview = overview
+		return nil
+	})
+
+	return mb
+}
+
 func (mb *MovieBuilder) WithReleaseDate(date time.Time) *MovieBuilder {
 	mb.functions = append(mb.functions, func(m *types.Movie) error {
 		m.ReleaseDate = date
@@ -47,3 +65,48 @@ func (mb *MovieBuilder) WithReleaseDate(date time.Time) *MovieBuilder {
 
 	return mb
 }
+
+func (mb *MovieBuilder) WithPopularity(popularity float64) *MovieBuilder {
+	mb.functions = append(mb.functions, func(m *types.Movie) error {
+		m.Popularity = popularity
+		return nil
+	})
+
+	return mb
+}
+
+func (mb *MovieBuilder) WithVoteCount(count int) *MovieBuilder {
+	mb.functions = append(mb.functions, func(m *types.Movie) error {
+		m.VoteCount = count
+		return nil
+	})
+
+	return mb
+}
+
+func (mb *MovieBuilder) WithPoster(path string) *MovieBuilder {
+	mb.functions = append(mb.functions, func(m *types.Movie) error {
+		m.Poster = path
+		return nil
+	})
+
+	return mb
+}
+
+func (mb *MovieBuilder) WithFanart(path string) *MovieBuilder {
+	mb.functions = append(mb.functions, func(m *types.Movie) error {
+		m.Fanart = path
+		return nil
+	})
+
+	return mb
+}
+
+func (mb *MovieBuilder) WithExternalIDs(ids types.ExternalIDs) *MovieBuilder {
+	mb.functions = append(mb.functions, func(m *types.Movie) error {
+		m.ExternalIDs = ids
+		return nil
+	})
+
+	return mb
+}
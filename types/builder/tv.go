@@ -1,8 +1,10 @@
 package builder
 
 import (
-	"github.com/rustedturnip/media-mapper/types"
 	"log"
+	"time"
+
+	"github.com/rustedturnip/media-mapper/types"
 )
 
 //Builder definitions
@@ -78,6 +80,15 @@ func (eb *EpisodeBuilder) Build() *types.Episode {
 }
 
 //TV Builder functions
+func (tvb *TVBuilder) WithID(id int) *TVBuilder {
+	tvb.functions = append(tvb.functions, func(tv *types.TV) error {
+		tv.ID = id
+		return nil
+	})
+
+	return tvb
+}
+
 func (tvb *TVBuilder) WithTitle(title string) *TVBuilder {
 	tvb.functions = append(tvb.functions, func(tv *types.TV) error {
 		tv.Title = title
@@ -87,6 +98,15 @@ func (tvb *TVBuilder) WithTitle(title string) *TVBuilder {
 	return tvb
 }
 
+func (tvb *TVBuilder) WithOverview(overview string) *TVBuilder {
+	tvb.functions = append(tvb.functions, func(tv *types.TV) error {
+		tv.Overview = overview
+		return nil
+	})
+
+	return tvb
+}
+
 func (tvb *TVBuilder) WithSeriesCount(count int) *TVBuilder {
 	tvb.functions = append(tvb.functions, func(tv *types.TV) error {
 		tv.SeriesCount = count
@@ -96,6 +116,69 @@ func (tvb *TVBuilder) WithSeriesCount(count int) *TVBuilder {
 	return tvb
 }
 
+func (tvb *TVBuilder) WithSeriesNumbers(numbers []int) *TVBuilder {
+	tvb.functions = append(tvb.functions, func(tv *types.TV) error {
+		tv.SeriesNumbers = numbers
+		return nil
+	})
+
+	return tvb
+}
+
+func (tvb *TVBuilder) WithReleaseDate(date time.Time) *TVBuilder {
+	tvb.functions = append(tvb.functions, func(tv *types.TV) error {
+		tv.ReleaseDate = date
+		return nil
+	})
+
+	return tvb
+}
+
+func (tvb *TVBuilder) WithPopularity(popularity float64) *TVBuilder {
+	tvb.functions = append(tvb.functions, func(tv *types.TV) error {
+		tv.Popularity = popularity
+		return nil
+	})
+
+	return tvb
+}
+
+func (tvb *TVBuilder) WithVoteCount(count int) *TVBuilder {
+	tvb.functions = append(tvb.functions, func(tv *types.TV) error {
+		tv.VoteCount = count
+		return nil
+	})
+
+	return tvb
+}
+
+func (tvb *TVBuilder) WithPoster(path string) *TVBuilder {
+	tvb.functions = append(tvb.functions, func(tv *types.TV) error {
+		tv.Poster = path
+		return nil
+	})
+
+	return tvb
+}
+
+func (tvb *TVBuilder) WithFanart(path string) *TVBuilder {
+	tvb.functions = append(tvb.functions, func(tv *types.TV) error {
+		tv.Fanart = path
+		return nil
+	})
+
+	return tvb
+}
+
+func (tvb *TVBuilder) WithExternalIDs(ids types.ExternalIDs) *TVBuilder {
+	tvb.functions = append(tvb.functions, func(tv *types.TV) error {
+		tv.ExternalIDs = ids
+		return nil
+	})
+
+	return tvb
+}
+
 func (tvb *TVBuilder) WithSeries(seriesBuilder *SeriesBuilder) *TVBuilder {
 	tvb.functions = append(tvb.functions, func(tv *types.TV) error {
 
@@ -108,6 +191,15 @@ func (tvb *TVBuilder) WithSeries(seriesBuilder *SeriesBuilder) *TVBuilder {
 }
 
 //Series Builder functions
+func (sb *SeriesBuilder) WithID(id int) *SeriesBuilder {
+	sb.functions = append(sb.functions, func(s *types.Series) error {
+		s.ID = id
+		return nil
+	})
+
+	return sb
+}
+
 func (sb *SeriesBuilder) WithTitle(title string) *SeriesBuilder {
 	sb.functions = append(sb.functions, func(s *types.Series) error {
 		s.Title = title
@@ -126,6 +218,15 @@ func (sb *SeriesBuilder) WithNumber(number int) *SeriesBuilder {
 	return sb
 }
 
+func (sb *SeriesBuilder) WithPoster(path string) *SeriesBuilder {
+	sb.functions = append(sb.functions, func(s *types.Series) error {
+		s.Poster = path
+		return nil
+	})
+
+	return sb
+}
+
 func (sb *SeriesBuilder) WithEpisode(episodeBuilder *EpisodeBuilder) *SeriesBuilder {
 	sb.functions = append(sb.functions, func(s *types.Series) error {
 
@@ -155,3 +256,48 @@ func (eb *EpisodeBuilder) WithNumber(number int) *EpisodeBuilder {
 
 	return eb
 }
+
+func (eb *EpisodeBuilder) WithID(id int) *EpisodeBuilder {
+	eb.functions = append(eb.functions, func(e *types.Episode) error {
+		e.ID = id
+		return nil
+	})
+
+	return eb
+}
+
+func (eb *EpisodeBuilder) WithOverview(overview string) *EpisodeBuilder {
+	eb.functions = append(eb.functions, func(e *types.Episode) error {
+		e.Overview = overview
+		return nil
+	})
+
+	return eb
+}
+
+func (eb *EpisodeBuilder) WithAbsoluteNumber(number int) *EpisodeBuilder {
+	eb.functions = append(eb.functions, func(e *types.Episode) error {
+		e.AbsoluteNumber = number
+		return nil
+	})
+
+	return eb
+}
+
+func (eb *EpisodeBuilder) WithAirDate(date time.Time) *EpisodeBuilder {
+	eb.functions = append(eb.functions, func(e *types.Episode) error {
+		e.AirDate = date
+		return nil
+	})
+
+	return eb
+}
+
+func (eb *EpisodeBuilder) WithExternalIDs(ids types.ExternalIDs) *EpisodeBuilder {
+	eb.functions = append(eb.functions, func(e *types.Episode) error {
+		e.ExternalIDs = ids
+		return nil
+	})
+
+	return eb
+}
@@ -3,26 +3,56 @@ package types
 import "time"
 
 type Movie struct {
+	ID          int //provider specific ID
 	Title       string
+	Overview    string
 	ReleaseDate time.Time
+	Popularity  float64
+	VoteCount   int
+	Poster      string //artwork paths, relative to the provider's image URL
+	Fanart      string
+	ExternalIDs ExternalIDs
 }
 
 type TV struct {
-	Title       string
-	SeriesCount int
-	ReleaseDate time.Time
-	Series      map[int]*Series
+	ID            int //provider specific ID
+	Title         string
+	Overview      string
+	SeriesCount   int
+	SeriesNumbers []int //numbers of the show's series in order, specials excluded, e.g. 2019 for shows numbered by year
+	ReleaseDate   time.Time
+	Popularity    float64
+	VoteCount     int
+	Poster        string //artwork paths, relative to the provider's image URL
+	Fanart        string
+	ExternalIDs   ExternalIDs
+	Series        map[int]*Series
 }
 
 type Series struct {
+	ID       int //provider specific ID, 0 if the provider has none
 	Title    string
 	Number   int
+	Poster   string //relative to the provider's image URL
 	Episodes map[int]*Episode
 }
 
 type Episode struct {
-	Title  string
-	Number int
+	ID             int //provider specific ID
+	Title          string
+	Number         int
+	AbsoluteNumber int //number counting from the show's first episode, 0 if the provider doesn't number them
+	Overview       string
+	AirDate        time.Time
+	ExternalIDs    ExternalIDs
+}
+
+//ExternalIDs are the IDs of media in databases other than the provider's,
+//empty if unknown
+type ExternalIDs struct {
+	IMDB string //e.g. tt0110357
+	TMDB int
+	TVDB int
 }
 
 //Constructors